@@ -1,12 +1,20 @@
 package sevcord
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+)
 
 type SlashCommandObject interface {
 	name() string
 	dg() *discordgo.ApplicationCommandOption
 	isGroup() bool
 	permissions() *int64
+	validate(v *ValidationError, path string, depth int)
+
+	Validate() error
 }
 
 // NOTE: Can only have 2 levels of subcommands
@@ -33,6 +41,12 @@ type SlashCommand struct {
 	Options     []Option
 	Permissions *int
 	Handler     SlashCommandHandler
+
+	// Optional, shown by /help and GenerateDocs
+	LongDescription string
+	Examples        []string // Example uses, like "/remind time:10m message:Stretch"
+
+	Text bool // Whether the command can be run from messages, see Sevcord.EnableTextCommands
 }
 
 func NewSlashCommand(name, description string, handler SlashCommandHandler, options ...Option) *SlashCommand {
@@ -45,6 +59,19 @@ func (s *SlashCommand) RequirePermissions(p int) *SlashCommand {
 	return s
 }
 
+// Document adds a long description and examples, which are shown by /help and GenerateDocs but not sent to discord
+func (s *SlashCommand) Document(longDescription string, examples ...string) *SlashCommand {
+	s.LongDescription = longDescription
+	s.Examples = examples
+	return s
+}
+
+// AllowText lets the command be run from messages when text commands are enabled. The handler is passed a *MessageCtx instead of an *InteractionCtx, so it can't open modals or rely on interaction-only features
+func (s *SlashCommand) AllowText() *SlashCommand {
+	s.Text = true
+	return s
+}
+
 func (s *SlashCommandGroup) name() string { return s.Name }
 func (s *SlashCommandGroup) dg() *discordgo.ApplicationCommandOption {
 	children := make([]*discordgo.ApplicationCommandOption, len(s.Children))
@@ -139,6 +166,16 @@ const (
 	OptionKindAttachment                   // *SlashCommandAttachment
 )
 
+var optionKindNames = [...]string{"string", "int", "bool", "user", "channel", "role", "float", "attachment"}
+
+// String gets the name of the kind, which is also the kind used in command definition files
+func (o OptionKind) String() string {
+	if o < OptionKindString || o > OptionKindAttachment {
+		return "OptionKind(" + strconv.Itoa(int(o)) + ")"
+	}
+	return optionKindNames[o]
+}
+
 func (o OptionKind) dg() discordgo.ApplicationCommandOptionType {
 	return [...]discordgo.ApplicationCommandOptionType{discordgo.ApplicationCommandOptionString, discordgo.ApplicationCommandOptionInteger, discordgo.ApplicationCommandOptionBoolean, discordgo.ApplicationCommandOptionUser, discordgo.ApplicationCommandOptionChannel, discordgo.ApplicationCommandOptionRole, discordgo.ApplicationCommandOptionNumber, discordgo.ApplicationCommandOptionAttachment}[o]
 }
@@ -209,9 +246,20 @@ const (
 )
 
 type ContextMenuCommand struct {
-	Kind    ContextMenuKind
-	Name    string
-	Handler ContextMenuHandler
+	Kind        ContextMenuKind
+	Name        string
+	Handler     ContextMenuHandler
+	Permissions *int
+}
+
+func NewContextMenu(kind ContextMenuKind, name string, handler ContextMenuHandler) *ContextMenuCommand {
+	return &ContextMenuCommand{Kind: kind, Name: name, Handler: handler}
+}
+
+// RequirePermissions accepts a discordgo permissions bit mask
+func (c *ContextMenuCommand) RequirePermissions(p int) *ContextMenuCommand {
+	c.Permissions = &p
+	return c
 }
 
 // Components
@@ -297,6 +345,7 @@ const (
 	ButtonStyleLink      ButtonStyle = 5
 )
 
+// Dg converts the button to discordgo as is. Like MessageSend.Dg, the custom ID isn't encoded, so use Sevcord.MessageDg instead if SetSigningKey is used
 func (b *Button) Dg() discordgo.MessageComponent {
 	v := discordgo.Button{
 		Label:    b.Label,
@@ -346,6 +395,9 @@ type Select struct {
 
 	// Fill this for channel select menu
 	ChannelFilter []discordgo.ChannelType
+
+	// Preselected values for user, role, mentionable and channel select menus (use SelectOption.Default for string select menus)
+	Defaults []SelectDefaultValue
 }
 
 func NewSelect(placeholder string, handler string, params string) *Select {
@@ -385,6 +437,13 @@ func (s *Select) ChannelMenuFilter(allowed ...discordgo.ChannelType) *Select {
 	return s
 }
 
+// DefaultValues preselects users, roles or channels in an auto-populated select menu, for example to show current settings
+func (s *Select) DefaultValues(values ...SelectDefaultValue) *Select {
+	s.Defaults = append(s.Defaults, values...)
+	return s
+}
+
+// Dg converts the select menu to discordgo as is. Like MessageSend.Dg, the custom ID isn't encoded, so use Sevcord.MessageDg instead if SetSigningKey is used
 func (s *Select) Dg() discordgo.MessageComponent {
 	v := discordgo.SelectMenu{
 		MenuType:     s.Kind.Dg(),
@@ -407,9 +466,64 @@ func (s *Select) Dg() discordgo.MessageComponent {
 			v.Options[i].Emoji = opt.Emoji.Dg()
 		}
 	}
+	if len(s.Defaults) > 0 {
+		return selectMenuWithDefaults{SelectMenu: v, defaults: s.Defaults}
+	}
 	return v
 }
 
+type SelectDefaultValueKind string
+
+const (
+	SelectDefaultValueUser    SelectDefaultValueKind = "user"
+	SelectDefaultValueRole    SelectDefaultValueKind = "role"
+	SelectDefaultValueChannel SelectDefaultValueKind = "channel"
+)
+
+type SelectDefaultValue struct {
+	ID   string
+	Kind SelectDefaultValueKind
+}
+
+func NewSelectDefaultUser(id string) SelectDefaultValue {
+	return SelectDefaultValue{ID: id, Kind: SelectDefaultValueUser}
+}
+
+func NewSelectDefaultRole(id string) SelectDefaultValue {
+	return SelectDefaultValue{ID: id, Kind: SelectDefaultValueRole}
+}
+
+func NewSelectDefaultChannel(id string) SelectDefaultValue {
+	return SelectDefaultValue{ID: id, Kind: SelectDefaultValueChannel}
+}
+
+// selectMenuWithDefaults adds default_values, which discordgo doesn't support, to a select menu
+type selectMenuWithDefaults struct {
+	discordgo.SelectMenu
+	defaults []SelectDefaultValue
+}
+
+func (s selectMenuWithDefaults) MarshalJSON() ([]byte, error) {
+	type selectMenu discordgo.SelectMenu
+	type defaultValue struct {
+		ID   string                 `json:"id"`
+		Type SelectDefaultValueKind `json:"type"`
+	}
+	defaults := make([]defaultValue, len(s.defaults))
+	for i, v := range s.defaults {
+		defaults[i] = defaultValue{ID: v.ID, Type: v.Kind}
+	}
+	return json.Marshal(struct {
+		selectMenu
+		Type          discordgo.ComponentType `json:"type"`
+		DefaultValues []defaultValue          `json:"default_values"`
+	}{
+		selectMenu:    selectMenu(s.SelectMenu),
+		Type:          s.Type(),
+		DefaultValues: defaults,
+	})
+}
+
 type SelectOption struct {
 	Label       string
 	Description string
@@ -439,12 +553,23 @@ func (s SelectOption) SetDefault(defaulted bool) SelectOption {
 }
 
 // Modals
-type ModalHandler func(Ctx, []string)
+
+// ModalValues maps the ID of each input to the value submitted for it
+type ModalValues map[string]string
+
+type ModalHandler func(Ctx, ModalValues)
+
+// NamedModalHandler handles submissions of modals created with NewNamedModal. Like buttons and selects, it is registered by ID so that modals can be routed after restarts
+type NamedModalHandler func(ctx Ctx, params string, values ModalValues)
 
 type Modal struct {
-	Title   string
-	Inputs  []ModalInput
-	Handler ModalHandler
+	Title  string
+	Inputs []ModalInput
+
+	// Use Handler for a one-off handler, or HandlerID and Params for a handler added with AddModalHandler
+	Handler   ModalHandler
+	HandlerID string // ID of handler
+	Params    string // Params to pass to handler
 }
 
 type ModalInputStyle int
@@ -455,12 +580,16 @@ const (
 )
 
 type ModalInput struct {
+	ID          string // Key of the value in ModalValues, defaults to the index of the input
 	Label       string
 	Placeholder string
 	Style       ModalInputStyle
 	Required    bool
 	MinLength   int
 	MaxLength   int
+
+	// Optional
+	Value string // Prefilled value
 }
 
 func NewModalInput(label, placeholder string, style ModalInputStyle, maxLength int) ModalInput {
@@ -479,6 +608,17 @@ func (m ModalInput) SetRequired(required bool) ModalInput {
 	return m
 }
 
+func (m ModalInput) SetID(id string) ModalInput {
+	m.ID = id
+	return m
+}
+
+// SetValue prefills the input, for example with the current value of whatever is being edited
+func (m ModalInput) SetValue(value string) ModalInput {
+	m.Value = value
+	return m
+}
+
 func (m ModalInput) SetLength(min, max int) ModalInput {
 	m.MinLength = min
 	m.MaxLength = max
@@ -493,6 +633,16 @@ func NewModal(title string, handler ModalHandler) Modal {
 	}
 }
 
+// NewNamedModal creates a modal that is handled by a handler added with AddModalHandler
+func NewNamedModal(title string, handler string, params string) Modal {
+	return Modal{
+		Title:     title,
+		HandlerID: handler,
+		Params:    params,
+		Inputs:    make([]ModalInput, 0),
+	}
+}
+
 func (m Modal) Input(inp ModalInput) Modal {
 	m.Inputs = append(m.Inputs, inp)
 	return m
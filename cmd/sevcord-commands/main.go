@@ -0,0 +1,326 @@
+// Command sevcord-commands deploys a command manifest written by Sevcord.ExportCommands to a discord application.
+//
+// Usage:
+//
+//	sevcord-commands [flags] sync|list|diff|delete [name...]
+//
+// sync overwrites the commands on discord with the manifest, list prints the commands on discord, diff compares the manifest with discord and delete removes the named commands (or every command in the manifest if no names are given).
+// Global commands and the commands of every guild in the manifest are used, pass -guild to only use one guild.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Nv7-Github/sevcord/v2"
+	"github.com/bwmarrin/discordgo"
+)
+
+type client struct {
+	base  string
+	token string
+	app   string
+	http  *http.Client
+}
+
+func (c *client) do(method, path string, body, out any) error {
+	var r io.Reader
+	if body != nil {
+		v, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(v)
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.base, "/")+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("%s %s: %s: %s", method, path, res.Status, bytes.TrimSpace(msg))
+	}
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+func (c *client) path(guild string) string {
+	if guild == "" {
+		return "/applications/" + c.app + "/commands"
+	}
+	return "/applications/" + c.app + "/guilds/" + guild + "/commands"
+}
+
+func (c *client) list(guild string) ([]*discordgo.ApplicationCommand, error) {
+	var out []*discordgo.ApplicationCommand
+	err := c.do(http.MethodGet, c.path(guild), nil, &out)
+	return out, err
+}
+
+func (c *client) overwrite(guild string, cmds []*discordgo.ApplicationCommand) ([]*discordgo.ApplicationCommand, error) {
+	var out []*discordgo.ApplicationCommand
+	err := c.do(http.MethodPut, c.path(guild), cmds, &out)
+	return out, err
+}
+
+func (c *client) delete(guild, id string) error {
+	return c.do(http.MethodDelete, c.path(guild)+"/"+id, nil, nil)
+}
+
+// scope is the commands for global ("") or a guild
+type scope struct {
+	guild string
+	cmds  []*discordgo.ApplicationCommand
+}
+
+func (s scope) String() string {
+	if s.guild == "" {
+		return "global"
+	}
+	return "guild " + s.guild
+}
+
+func readManifest(file string) (*sevcord.CommandManifest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out sevcord.CommandManifest
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return &out, nil
+}
+
+// scopes gets the scopes to act on, a nil manifest is treated as empty
+func scopes(manifest *sevcord.CommandManifest, guild string) []scope {
+	if manifest == nil {
+		manifest = &sevcord.CommandManifest{}
+	}
+	if guild != "" {
+		return []scope{{guild, manifest.Guilds[guild]}}
+	}
+	out := []scope{{"", manifest.Global}}
+	guilds := make([]string, 0, len(manifest.Guilds))
+	for g := range manifest.Guilds {
+		guilds = append(guilds, g)
+	}
+	sort.Strings(guilds)
+	for _, g := range guilds {
+		out = append(out, scope{g, manifest.Guilds[g]})
+	}
+	return out
+}
+
+// normalize clears the fields discord fills in so that commands from discord can be compared with the manifest
+func normalize(cmd *discordgo.ApplicationCommand) *discordgo.ApplicationCommand {
+	v := *cmd
+	v.ID = ""
+	v.ApplicationID = ""
+	v.GuildID = ""
+	v.Version = ""
+	v.DefaultPermission = nil
+	if v.DMPermission != nil && *v.DMPermission {
+		v.DMPermission = nil
+	}
+	if v.Type == 0 {
+		v.Type = discordgo.ChatApplicationCommand
+	}
+	v.Options = normalizeOptions(v.Options)
+	return &v
+}
+
+func normalizeOptions(opts []*discordgo.ApplicationCommandOption) []*discordgo.ApplicationCommandOption {
+	if len(opts) == 0 {
+		return nil
+	}
+	out := make([]*discordgo.ApplicationCommandOption, len(opts))
+	for i, opt := range opts {
+		v := *opt
+		v.Options = normalizeOptions(v.Options)
+		if len(v.Choices) == 0 {
+			v.Choices = nil
+		}
+		if len(v.ChannelTypes) == 0 {
+			v.ChannelTypes = nil
+		}
+		out[i] = &v
+	}
+	return out
+}
+
+func byName(cmds []*discordgo.ApplicationCommand) map[string]*discordgo.ApplicationCommand {
+	out := make(map[string]*discordgo.ApplicationCommand, len(cmds))
+	for _, cmd := range cmds {
+		out[cmd.Name] = cmd
+	}
+	return out
+}
+
+// diff prints the changes sync would make, returning whether there are any
+func diff(w io.Writer, sc scope, remote []*discordgo.ApplicationCommand) bool {
+	local := byName(sc.cmds)
+	current := byName(remote)
+	names := make([]string, 0, len(local)+len(current))
+	for name := range local {
+		names = append(names, name)
+	}
+	for name := range current {
+		if _, exists := local[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changed := false
+	for _, name := range names {
+		l, inLocal := local[name]
+		r, inRemote := current[name]
+		switch {
+		case !inRemote:
+			fmt.Fprintf(w, "+ %s: /%s\n", sc, name)
+		case !inLocal:
+			fmt.Fprintf(w, "- %s: /%s\n", sc, name)
+		case !reflect.DeepEqual(normalize(l), normalize(r)):
+			fmt.Fprintf(w, "~ %s: /%s\n", sc, name)
+		default:
+			continue
+		}
+		changed = true
+	}
+	return changed
+}
+
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sevcord-commands", flag.ContinueOnError)
+	token := fs.String("token", os.Getenv("DISCORD_TOKEN"), "bot token, defaults to $DISCORD_TOKEN")
+	app := fs.String("app", "", "application ID")
+	guild := fs.String("guild", "", "only use the commands of this guild")
+	file := fs.String("manifest", "commands.json", "manifest written by Sevcord.ExportCommands")
+	base := fs.String("base", "https://discord.com/api/v10", "discord REST API base URL")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: sevcord-commands [flags] sync|list|diff|delete [name...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("missing action")
+	}
+	if *app == "" || *token == "" {
+		return errors.New("-app and -token are required")
+	}
+	c := &client{base: *base, token: strings.TrimSpace(*token), app: *app, http: http.DefaultClient}
+
+	action := fs.Arg(0)
+	manifest, err := readManifest(*file)
+	if err != nil && !(action == "list" && errors.Is(err, os.ErrNotExist)) {
+		return err
+	}
+
+	switch action {
+	case "sync":
+		for _, sc := range scopes(manifest, *guild) {
+			cmds := sc.cmds
+			if cmds == nil {
+				cmds = []*discordgo.ApplicationCommand{}
+			}
+			res, err := c.overwrite(sc.guild, cmds)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "%s: synced %d commands\n", sc, len(res))
+		}
+
+	case "list":
+		for _, sc := range scopes(manifest, *guild) {
+			cmds, err := c.list(sc.guild)
+			if err != nil {
+				return err
+			}
+			for _, cmd := range cmds {
+				fmt.Fprintf(stdout, "%s\t%s\t/%s\t%s\n", sc, cmd.ID, cmd.Name, cmd.Description)
+			}
+		}
+
+	case "diff":
+		changed := false
+		for _, sc := range scopes(manifest, *guild) {
+			remote, err := c.list(sc.guild)
+			if err != nil {
+				return err
+			}
+			if diff(stdout, sc, remote) {
+				changed = true
+			}
+		}
+		if !changed {
+			fmt.Fprintln(stdout, "Up to date")
+		}
+
+	case "delete":
+		names := make(map[string]struct{}, fs.NArg()-1)
+		for _, name := range fs.Args()[1:] {
+			names[name] = struct{}{}
+		}
+		for _, sc := range scopes(manifest, *guild) {
+			local := byName(sc.cmds)
+			remote, err := c.list(sc.guild)
+			if err != nil {
+				return err
+			}
+			for _, cmd := range remote {
+				_, named := names[cmd.Name]
+				_, inManifest := local[cmd.Name]
+				if (len(names) > 0 && !named) || (len(names) == 0 && !inManifest) {
+					continue
+				}
+				if err := c.delete(sc.guild, cmd.ID); err != nil {
+					return err
+				}
+				fmt.Fprintf(stdout, "%s: deleted /%s\n", sc, cmd.Name)
+			}
+		}
+
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown action %q", action)
+	}
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "sevcord-commands:", err)
+		}
+		os.Exit(1)
+	}
+}
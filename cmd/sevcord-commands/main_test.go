@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Nv7-Github/sevcord/v2"
+	"github.com/bwmarrin/discordgo"
+)
+
+const testApp = "1000"
+const testGuild = "2000"
+
+// fakeDiscord implements the application command endpoints used by the CLI, keeping commands in memory
+type fakeDiscord struct {
+	lock   sync.Mutex
+	nextID int
+	cmds   map[string][]*discordgo.ApplicationCommand // Guild ID, "" for global -> commands
+}
+
+func (f *fakeDiscord) add(guild string, cmd *discordgo.ApplicationCommand) {
+	f.nextID++
+	cmd.ID = strconv.Itoa(f.nextID)
+	cmd.ApplicationID = testApp
+	cmd.GuildID = guild
+	cmd.Version = "1"
+	f.cmds[guild] = append(f.cmds[guild], cmd)
+}
+
+func (f *fakeDiscord) names(guild string) []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	out := make([]string, 0, len(f.cmds[guild]))
+	for _, cmd := range f.cmds[guild] {
+		out = append(out, cmd.Name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (f *fakeDiscord) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bot token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "applications" || parts[1] != testApp {
+		http.NotFound(w, r)
+		return
+	}
+	parts = parts[2:]
+	guild := ""
+	if parts[0] == "guilds" && len(parts) >= 3 {
+		guild = parts[1]
+		parts = parts[2:]
+	}
+	if parts[0] != "commands" || len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		cmds := f.cmds[guild]
+		if cmds == nil {
+			cmds = []*discordgo.ApplicationCommand{}
+		}
+		json.NewEncoder(w).Encode(cmds)
+
+	case r.Method == http.MethodPut && len(parts) == 1:
+		var cmds []*discordgo.ApplicationCommand
+		if err := json.NewDecoder(r.Body).Decode(&cmds); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.cmds[guild] = nil
+		for _, cmd := range cmds {
+			f.add(guild, cmd)
+		}
+		json.NewEncoder(w).Encode(f.cmds[guild])
+
+	case r.Method == http.MethodDelete && len(parts) == 2:
+		cmds := f.cmds[guild]
+		for i, cmd := range cmds {
+			if cmd.ID == parts[1] {
+				f.cmds[guild] = append(cmds[:i], cmds[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// writeManifest exports the commands of a bot with global /ping and /help commands and a /secret command in testGuild
+func writeManifest(t *testing.T) string {
+	bot, err := sevcord.New("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := func(ctx sevcord.Ctx, args []any) {}
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("ping", "Checks if the bot is online", handler)); err != nil {
+		t.Fatal(err)
+	}
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("help", "Shows the commands", handler,
+		sevcord.NewOption("command", "The command to show", sevcord.OptionKindString, false),
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if err := bot.RegisterGuildSlashCommand(testGuild, sevcord.NewSlashCommand("secret", "A secret command", handler)); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "commands.json")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := bot.ExportCommands(f); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestRun(t *testing.T) {
+	discord := &fakeDiscord{cmds: make(map[string][]*discordgo.ApplicationCommand)}
+	discord.add("", &discordgo.ApplicationCommand{Name: "old", Description: "Removed from the bot"})
+	server := httptest.NewServer(discord)
+	defer server.Close()
+
+	file := writeManifest(t)
+	cli := func(args ...string) string {
+		t.Helper()
+		out := &strings.Builder{}
+		args = append([]string{"-token", "token", "-app", testApp, "-base", server.URL, "-manifest", file}, args...)
+		if err := run(args, out); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return out.String()
+	}
+	expect := func(what, got, want string) {
+		t.Helper()
+		if got != want {
+			t.Errorf("%s: got\n%s\nwant\n%s", what, got, want)
+		}
+	}
+	expectNames := func(guild string, want ...string) {
+		t.Helper()
+		if got := discord.names(guild); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("commands in %q: got %v, want %v", guild, got, want)
+		}
+	}
+
+	expect("diff before sync", cli("diff"), "+ global: /help\n- global: /old\n+ global: /ping\n+ guild 2000: /secret\n")
+
+	expect("sync", cli("sync"), "global: synced 2 commands\nguild 2000: synced 1 commands\n")
+	expectNames("", "help", "ping")
+	expectNames(testGuild, "secret")
+	expect("diff after sync", cli("diff"), "Up to date\n")
+
+	list := cli("-guild", testGuild, "list")
+	if !strings.Contains(list, "guild 2000\t") || !strings.Contains(list, "\t/secret\tA secret command\n") || strings.Contains(list, "/ping") {
+		t.Errorf("list: unexpected output\n%s", list)
+	}
+
+	// Commands that aren't in the manifest are only deleted when they are named
+	discord.lock.Lock()
+	discord.add("", &discordgo.ApplicationCommand{Name: "manual", Description: "Created outside of the manifest"})
+	discord.lock.Unlock()
+	expect("delete named", cli("delete", "ping", "missing"), "global: deleted /ping\n")
+	expectNames("", "help", "manual")
+	expectNames(testGuild, "secret")
+
+	expect("delete manifest", cli("delete"), "global: deleted /help\nguild 2000: deleted /secret\n")
+	expectNames("", "manual")
+	expectNames(testGuild)
+
+	expect("delete outside manifest", cli("delete", "manual"), "global: deleted /manual\n")
+	expectNames("")
+}
+
+func TestRunErrors(t *testing.T) {
+	discord := &fakeDiscord{cmds: make(map[string][]*discordgo.ApplicationCommand)}
+	server := httptest.NewServer(discord)
+	defer server.Close()
+
+	file := writeManifest(t)
+	tests := map[string][]string{
+		"missing action": {"-token", "token", "-app", testApp},
+		"missing app":    {"-token", "token", "sync"},
+		"unknown action": {"-token", "token", "-app", testApp, "-base", server.URL, "-manifest", file, "deploy"},
+		"bad token":      {"-token", "wrong", "-app", testApp, "-base", server.URL, "-manifest", file, "sync"},
+		"no manifest":    {"-token", "token", "-app", testApp, "-base", server.URL, "-manifest", filepath.Join(t.TempDir(), "missing.json"), "sync"},
+	}
+	for name, args := range tests {
+		out := &strings.Builder{}
+		if err := run(args, out); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package sevcord
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// collectHandler is the ID of the button and select handler that routes interactions to collectors
+const collectHandler = "sevcord.collect"
+
+// ErrAwaitTimeout is returned when no matching component interaction is received before the timeout
+var ErrAwaitTimeout = errors.New("sevcord: timed out waiting for component")
+
+// ComponentEvent is a button press or select menu submission received by AwaitComponent
+type ComponentEvent struct {
+	Ctx      *InteractionCtx // Use this to respond to the interaction
+	Handler  string          // Handler ID of the component that was used
+	Params   string          // Params of the component that was used
+	Selected []string        // Only for select menus
+}
+
+// ComponentFilter decides whether AwaitComponent should accept a component interaction. Rejected interactions are told that they can't use the component
+type ComponentFilter func(ev *ComponentEvent) bool
+
+// FilterUsers only accepts component interactions from the specified users
+func FilterUsers(ids ...string) ComponentFilter {
+	return func(ev *ComponentEvent) bool {
+		user := interactionUser(ev.Ctx.i).ID
+		for _, id := range ids {
+			if id == user {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// errUnsupportedCtx is returned by helpers that need a context created by sevcord
+var errUnsupportedCtx = errors.New("sevcord: ctx must be an *InteractionCtx or *MessageCtx")
+
+// ctxEditable gets the bot that created a context and the context's editable responses, returning an error for contexts not created by sevcord
+func ctxEditable(ctx Ctx) (*Sevcord, editableCtx, error) {
+	s := ctxSevcord(ctx)
+	v, ok := ctx.(editableCtx)
+	if s == nil || !ok {
+		return nil, nil, errUnsupportedCtx
+	}
+	return s, v, nil
+}
+
+// AwaitComponent responds to ctx with msg and waits for one of its components to be used in a way that filter (optional) accepts. The components' handlers aren't called, use the returned ComponentEvent instead. Use 0 for no timeout
+func AwaitComponent(c context.Context, ctx Ctx, msg MessageSend, filter ComponentFilter, timeout time.Duration) (*ComponentEvent, error) {
+	s, v, err := ctxEditable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ev, _, err := s.awaitComponent(c, v, msg, filter, timeout)
+	return ev, err
+}
+
+type collector struct {
+	filter ComponentFilter
+	events chan *ComponentEvent
+}
+
+// awaitComponent responds with a message, with all of its components routed to a collector, and waits for a matching interaction. The returned editor can edit the message's components
+func (s *Sevcord) awaitComponent(c context.Context, ctx editableCtx, msg MessageSend, filter ComponentFilter, timeout time.Duration) (*ComponentEvent, componentEditor, error) {
+	key := randomKey()
+	grid := make(componentGrid, len(msg.components))
+	for i, row := range msg.components {
+		grid[i] = make([]Component, len(row))
+		for j, comp := range row {
+			switch v := comp.(type) {
+			case *Button:
+				b := *v
+				if b.Style != ButtonStyleLink {
+					b.Handler = collectHandler
+					b.Params = strings.Join([]string{key, v.Handler, v.Params}, componentSeperator)
+				}
+				grid[i][j] = &b
+
+			case *Select:
+				sel := *v
+				sel.Handler = collectHandler
+				sel.Params = strings.Join([]string{key, v.Handler, v.Params}, componentSeperator)
+				grid[i][j] = &sel
+
+			default:
+				grid[i][j] = comp
+			}
+		}
+	}
+	msg.components = grid
+
+	col := &collector{
+		filter: filter,
+		events: make(chan *ComponentEvent, 1),
+	}
+	s.lock.Lock()
+	s.collectors[key] = col
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		delete(s.collectors, key)
+		s.lock.Unlock()
+	}()
+
+	edit, err := ctx.respondEditable(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case ev := <-col.events:
+		return ev, edit, nil
+
+	case <-expired:
+		return nil, edit, ErrAwaitTimeout
+
+	case <-c.Done():
+		return nil, edit, c.Err()
+	}
+}
+
+func (s *Sevcord) collect(ctx Ctx, params string, selected []string) {
+	ictx := ctx.(*InteractionCtx)
+	parts := strings.SplitN(params, componentSeperator, 3)
+	if len(parts) != 3 {
+		ictx.respondError("This component is invalid.")
+		return
+	}
+	s.lock.RLock()
+	col, exists := s.collectors[parts[0]]
+	s.lock.RUnlock()
+	if !exists {
+		ictx.respondError("This component has expired.")
+		return
+	}
+
+	ev := &ComponentEvent{
+		Ctx:      ictx,
+		Handler:  parts[1],
+		Params:   parts[2],
+		Selected: selected,
+	}
+	if col.filter != nil && !col.filter(ev) {
+		ictx.respondError("You can't use this component.")
+		return
+	}
+
+	// Only deliver one event per collector
+	s.lock.Lock()
+	_, exists = s.collectors[parts[0]]
+	delete(s.collectors, parts[0])
+	s.lock.Unlock()
+	if !exists {
+		ictx.respondError("This component has expired.")
+		return
+	}
+	col.events <- ev
+}
@@ -0,0 +1,214 @@
+package sevcord
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// commandDg converts a command to the payload sent to discord
+func commandDg(cmd SlashCommandObject, global bool) *discordgo.ApplicationCommand {
+	v := cmd.dg()
+	out := &discordgo.ApplicationCommand{
+		Name:        v.Name,
+		Description: v.Description,
+		Options:     v.Options,
+		Type:        discordgo.ChatApplicationCommand,
+
+		DefaultMemberPermissions: cmd.permissions(),
+	}
+	if global {
+		dmPermission := false
+		out.DMPermission = &dmPermission
+	}
+	return out
+}
+
+// contextMenuKey identifies a context menu, user and message context menus can have the same name
+type contextMenuKey struct {
+	kind ContextMenuKind
+	name string
+}
+
+// contextMenuDg converts a context menu to the payload sent to discord
+func contextMenuDg(cmd *ContextMenuCommand) *discordgo.ApplicationCommand {
+	dmPermission := false
+	out := &discordgo.ApplicationCommand{
+		Name:         cmd.Name,
+		Type:         discordgo.ApplicationCommandType(cmd.Kind),
+		DMPermission: &dmPermission,
+	}
+	if cmd.Permissions != nil {
+		v := int64(*cmd.Permissions)
+		out.DefaultMemberPermissions = &v
+	}
+	return out
+}
+
+// RegisterContextMenu registers a global context menu command. If the bot is connected, the command is created immediately
+func (s *Sevcord) RegisterContextMenu(cmd *ContextMenuCommand) error {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	s.contextMenus[contextMenuKey{cmd.Kind, cmd.Name}] = cmd
+	appID := s.appID
+	s.lock.Unlock()
+	if appID == "" { // Not connected yet, the command will be created when ready
+		return nil
+	}
+
+	_, err := s.dg.ApplicationCommandCreate(appID, "", contextMenuDg(cmd))
+	return err
+}
+
+// commandsFor gets the commands registered for a guild, use "" for global commands. The lock must be held
+func (s *Sevcord) commandsFor(guild string) map[string]SlashCommandObject {
+	if guild == "" {
+		return s.commands
+	}
+	cmds, exists := s.guildCommands[guild]
+	if !exists {
+		cmds = make(map[string]SlashCommandObject)
+		s.guildCommands[guild] = cmds
+	}
+	return cmds
+}
+
+// findCommand gets the command with the name, preferring commands registered in the guild
+func (s *Sevcord) findCommand(guild, name string) (SlashCommandObject, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if guild != "" {
+		if v, exists := s.guildCommands[guild][name]; exists {
+			return v, true
+		}
+	}
+	v, exists := s.commands[name]
+	return v, exists
+}
+
+// RegisterGuildSlashCommand registers a command that is only available in one guild. If the bot is connected, the command is created immediately
+func (s *Sevcord) RegisterGuildSlashCommand(guild string, cmd SlashCommandObject) error {
+	return s.registerCommand(guild, cmd)
+}
+
+// UnregisterSlashCommand removes a global command. If the bot is connected, the command is deleted immediately
+func (s *Sevcord) UnregisterSlashCommand(name string) error {
+	return s.unregisterCommand("", name)
+}
+
+// UnregisterGuildSlashCommand removes a command registered with RegisterGuildSlashCommand. If the bot is connected, the command is deleted immediately
+func (s *Sevcord) UnregisterGuildSlashCommand(guild, name string) error {
+	return s.unregisterCommand(guild, name)
+}
+
+func (s *Sevcord) registerCommand(guild string, cmd SlashCommandObject) error {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	s.commandsFor(guild)[cmd.name()] = cmd
+	if guild == "" { // The command no longer belongs to the module that registered it
+		delete(s.commandModules, cmd.name())
+	}
+	s.lock.Unlock()
+
+	return s.syncCommand(guild, cmd)
+}
+
+func (s *Sevcord) unregisterCommand(guild, name string) error {
+	s.lock.Lock()
+	cmds := s.commandsFor(guild)
+	_, exists := cmds[name]
+	delete(cmds, name)
+	if guild == "" {
+		delete(s.commandModules, name)
+	}
+	s.lock.Unlock()
+	if !exists {
+		return fmt.Errorf("sevcord: command %s isn't registered", name)
+	}
+
+	return s.syncDelete(guild, name)
+}
+
+// syncCommand creates or updates a command on discord if the bot is connected
+func (s *Sevcord) syncCommand(guild string, cmd SlashCommandObject) error {
+	s.lock.RLock()
+	appID := s.appID
+	s.lock.RUnlock()
+	if appID == "" { // Not connected yet, the command will be created when ready
+		return nil
+	}
+
+	v, err := s.dg.ApplicationCommandCreate(appID, guild, commandDg(cmd, guild == ""))
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	s.commandIDsFor(guild)[v.Name] = v.ID
+	s.lock.Unlock()
+	return nil
+}
+
+// syncDelete deletes a command on discord if the bot is connected
+func (s *Sevcord) syncDelete(guild, name string) error {
+	s.lock.Lock()
+	appID := s.appID
+	ids := s.commandIDsFor(guild)
+	id, exists := ids[name]
+	delete(ids, name)
+	s.lock.Unlock()
+	if appID == "" || !exists {
+		return nil
+	}
+
+	return s.dg.ApplicationCommandDelete(appID, guild, id)
+}
+
+// commandIDsFor gets the IDs of the commands created on discord for a guild, use "" for global commands. The lock must be held
+func (s *Sevcord) commandIDsFor(guild string) map[string]string {
+	ids, exists := s.commandIDs[guild]
+	if !exists {
+		ids = make(map[string]string)
+		s.commandIDs[guild] = ids
+	}
+	return ids
+}
+
+// syncAll overwrites all global and guild commands on discord with the registered commands
+func (s *Sevcord) syncAll(appID string) error {
+	s.lock.Lock()
+	s.appID = appID
+	manifest := s.commandPayloads()
+	s.lock.Unlock()
+
+	payloads := make(map[string][]*discordgo.ApplicationCommand, len(manifest.Guilds)+1)
+	payloads[""] = manifest.Global
+	for guild, cmds := range manifest.Guilds {
+		payloads[guild] = cmds
+	}
+
+	var firstErr error
+	for guild, cmds := range payloads {
+		res, err := s.dg.ApplicationCommandBulkOverwrite(appID, guild, cmds)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		s.lock.Lock()
+		ids := make(map[string]string, len(res))
+		for _, v := range res {
+			if v.Type == discordgo.ChatApplicationCommand { // Context menus can't be unregistered
+				ids[v.Name] = v.ID
+			}
+		}
+		s.commandIDs[guild] = ids
+		s.lock.Unlock()
+	}
+	return firstErr
+}
@@ -0,0 +1,74 @@
+package sevcord
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultConfirmTimeout is how long Confirm waits for by default
+const DefaultConfirmTimeout = time.Minute
+
+// ConfirmOptions customizes a confirmation dialog, all fields are optional
+type ConfirmOptions struct {
+	ConfirmLabel string        // Defaults to "Confirm"
+	CancelLabel  string        // Defaults to "Cancel"
+	ConfirmStyle ButtonStyle   // Defaults to ButtonStyleDanger
+	Timeout      time.Duration // Defaults to DefaultConfirmTimeout
+
+	// Called with the context of the button press, after the buttons have been disabled. They aren't called on timeout
+	OnConfirm func(ctx Ctx)
+	OnCancel  func(ctx Ctx)
+}
+
+// Confirm asks the author of ctx to confirm or cancel an action, returning whether they confirmed. Only the author can use the buttons, which are disabled once one is pressed or the timeout passes. ErrAwaitTimeout is returned on timeout
+func Confirm(ctx Ctx, prompt string, opts ConfirmOptions) (bool, error) {
+	s, editable, err := ctxEditable(ctx)
+	if err != nil {
+		return false, err
+	}
+	if opts.ConfirmLabel == "" {
+		opts.ConfirmLabel = "Confirm"
+	}
+	if opts.CancelLabel == "" {
+		opts.CancelLabel = "Cancel"
+	}
+	if opts.ConfirmStyle == 0 {
+		opts.ConfirmStyle = ButtonStyleDanger
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultConfirmTimeout
+	}
+	msg := func(disabled bool) MessageSend {
+		return NewMessage(prompt).AddComponentRow(
+			NewButton(opts.ConfirmLabel, opts.ConfirmStyle, "confirm", "").SetDisabled(disabled),
+			NewButton(opts.CancelLabel, ButtonStyleSecondary, "cancel", "").SetDisabled(disabled),
+		)
+	}
+
+	ev, edit, err := s.awaitComponent(context.Background(), editable, msg(false), FilterUsers(ctxUser(ctx).ID), opts.Timeout)
+	if err == ErrAwaitTimeout {
+		dg, dgErr := s.messageDg(msg(true))
+		if dgErr == nil {
+			dgErr = edit(dg.Components)
+		}
+		if dgErr != nil {
+			Logger.Println("Error disabling confirmation", dgErr)
+		}
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	confirmed := ev.Handler == "confirm"
+	if err := ev.Ctx.Respond(msg(true)); err != nil {
+		return confirmed, err
+	}
+	if confirmed && opts.OnConfirm != nil {
+		opts.OnConfirm(ev.Ctx)
+	}
+	if !confirmed && opts.OnCancel != nil {
+		opts.OnCancel(ev.Ctx)
+	}
+	return confirmed, nil
+}
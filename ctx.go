@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"io"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -17,6 +18,9 @@ type MessageSend struct {
 		reader      io.Reader
 	}
 	components componentGrid
+
+	expiry        time.Duration
+	removeExpired bool
 }
 
 type componentGrid [][]Component
@@ -58,6 +62,14 @@ type Ctx interface {
 	Guild() string
 }
 
+// componentEditor replaces the components of a message that has been sent
+type componentEditor func(components []discordgo.MessageComponent) error
+
+// editableCtx is implemented by contexts that can edit their responses
+type editableCtx interface {
+	respondEditable(msg MessageSend) (componentEditor, error)
+}
+
 // Builder methods
 func NewEmbed() EmbedBuilder {
 	return EmbedBuilder{fields: make([]struct {
@@ -177,6 +189,13 @@ func (m MessageSend) AddComponentRow(components ...Component) MessageSend {
 	return m
 }
 
+// Expire disables the message's buttons and select menus, or removes them if remove is true, after the duration passes. Components used after they expire are responded to with an error instead of calling their handlers. Note that interaction responses can only be edited for 15 minutes
+func (m MessageSend) Expire(after time.Duration, remove bool) MessageSend {
+	m.expiry = after
+	m.removeExpired = remove
+	return m
+}
+
 func (e EmbedBuilder) Dg() *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		URL:         e.url,
@@ -220,6 +239,7 @@ func (e EmbedBuilder) Dg() *discordgo.MessageEmbed {
 	return embed
 }
 
+// Dg converts the message to discordgo as is. Note that component custom IDs aren't signed, shortened using the state store or given an expiry, so components sent with it are rejected once SetSigningKey is used. Use Sevcord.MessageDg to send messages with discordgo directly
 func (m MessageSend) Dg() *discordgo.MessageSend {
 	msg := &discordgo.MessageSend{
 		Content:    m.content,
@@ -240,6 +260,31 @@ func (m MessageSend) Dg() *discordgo.MessageSend {
 	return msg
 }
 
+// disabled returns a copy of the grid with every button and select menu disabled
+func (c componentGrid) disabled() componentGrid {
+	out := make(componentGrid, len(c))
+	for i, row := range c {
+		out[i] = make([]Component, len(row))
+		for j, comp := range row {
+			switch v := comp.(type) {
+			case *Button:
+				b := *v
+				b.Disabled = true
+				out[i][j] = &b
+
+			case *Select:
+				sel := *v
+				sel.Disabled = true
+				out[i][j] = &sel
+
+			default:
+				out[i][j] = comp
+			}
+		}
+	}
+	return out
+}
+
 func (c componentGrid) Dg() []discordgo.MessageComponent {
 	components := make([]discordgo.MessageComponent, len(c))
 	for i, row := range c {
@@ -2,6 +2,7 @@ package sevcord
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -10,6 +11,7 @@ import (
 type MessageCtx struct {
 	m            *discordgo.Message
 	d            *discordgo.Session
+	s            *Sevcord
 	acknowledged bool
 }
 
@@ -26,14 +28,42 @@ func (m *MessageCtx) Acknowledge() error {
 }
 
 func (m *MessageCtx) Respond(msg MessageSend) error {
-	v := msg.Dg()
+	_, err := m.respondEditable(msg)
+	return err
+}
+
+func (m *MessageCtx) respondEditable(msg MessageSend) (componentEditor, error) {
+	edit, key, err := m.send(msg)
+	if err != nil {
+		return nil, err
+	}
+	m.s.scheduleExpiry(msg, edit, key)
+	return edit, nil
+}
+
+// send sends a message, returning an editor for its components and the key used for its expiry
+func (m *MessageCtx) send(msg MessageSend) (componentEditor, string, error) {
+	v, err := m.s.messageDg(msg)
+	if err != nil {
+		return nil, "", err
+	}
 	v.Reference = &discordgo.MessageReference{
 		MessageID: m.m.ID,
 		ChannelID: m.m.ChannelID,
 		GuildID:   m.m.GuildID,
 	}
-	_, err := m.d.ChannelMessageSendComplex(m.m.ChannelID, v)
-	return err
+	sent, err := m.d.ChannelMessageSendComplex(m.m.ChannelID, v)
+	if err != nil {
+		return nil, "", err
+	}
+	return func(comps []discordgo.MessageComponent) error {
+		// discordgo.MessageEdit always sends embeds, so only send the components to keep the message's current content and embeds
+		endpoint := discordgo.EndpointChannelMessage(sent.ChannelID, sent.ID)
+		_, err := m.d.RequestWithBucketID("PATCH", endpoint, struct {
+			Components []discordgo.MessageComponent `json:"components"`
+		}{comps}, discordgo.EndpointChannelMessage(sent.ChannelID, ""))
+		return err
+	}, sent.ID, nil
 }
 
 func (m *MessageCtx) Author() *discordgo.Member {
@@ -60,8 +90,12 @@ type InteractionCtx struct {
 	i            *discordgo.Interaction
 	s            *Sevcord
 	acknowledged bool
+	responded    bool // If already responded, then send followup messages
 	component    bool // If component, then update
 	modal        bool
+
+	selectResolved *SelectResolved
+	routeParams    map[string]string
 }
 
 func (i *InteractionCtx) Dg() *discordgo.Session {
@@ -83,22 +117,56 @@ func (i *InteractionCtx) Acknowledge() error {
 }
 
 func (i *InteractionCtx) Respond(msg MessageSend) error {
-	b := msg.Dg()
-	if i.acknowledged && !i.component {
-		_, err := i.dg.FollowupMessageCreate(i.i, true, &discordgo.WebhookParams{
+	_, err := i.respondEditable(msg)
+	return err
+}
+
+func (i *InteractionCtx) respondEditable(msg MessageSend) (componentEditor, error) {
+	edit, key, err := i.send(msg)
+	if err != nil {
+		return nil, err
+	}
+	i.s.scheduleExpiry(msg, edit, key)
+	return edit, nil
+}
+
+// send sends a message or updates the component's message, returning an editor for its components and the key used for its expiry
+func (i *InteractionCtx) send(msg MessageSend) (componentEditor, string, error) {
+	b, err := i.s.messageDg(msg)
+	if err != nil {
+		return nil, "", err
+	}
+	editOriginal := func(comps []discordgo.MessageComponent) error {
+		_, err := i.dg.InteractionResponseEdit(i.i, &discordgo.WebhookEdit{Components: &comps})
+		return err
+	}
+	if (i.acknowledged && !i.component) || i.responded {
+		m, err := i.dg.FollowupMessageCreate(i.i, true, &discordgo.WebhookParams{
 			Content:    b.Content,
 			Files:      b.Files,
 			Embeds:     b.Embeds,
 			Components: b.Components,
 		})
-		return err
+		if err != nil {
+			return nil, "", err
+		}
+		return func(comps []discordgo.MessageComponent) error {
+			_, err := i.dg.FollowupMessageEdit(i.i, m.ID, &discordgo.WebhookEdit{Components: &comps})
+			return err
+		}, m.ID, nil
 	}
+	i.responded = true
 	if i.component && !i.acknowledged { // if not acknowledged, then update instead of ephemeral (no non-ephemeral response allowed on components since no one needs that)
 		typ := discordgo.InteractionResponseUpdateMessage
+		key := ""
 		if i.modal {
 			typ = discordgo.InteractionResponseChannelMessageWithSource
+			key = i.i.ID
+		} else if keys := messageKeys(i.i.Message); len(keys) > 0 {
+			i.s.cancelExpiry(keys...) // The message is being replaced
+			key = keys[0]
 		}
-		return i.dg.InteractionRespond(i.i, &discordgo.InteractionResponse{
+		return editOriginal, key, i.dg.InteractionRespond(i.i, &discordgo.InteractionResponse{
 			Type: typ,
 			Data: &discordgo.InteractionResponseData{
 				Content:    b.Content,
@@ -108,7 +176,7 @@ func (i *InteractionCtx) Respond(msg MessageSend) error {
 			},
 		})
 	}
-	return i.dg.InteractionRespond(i.i, &discordgo.InteractionResponse{
+	return editOriginal, i.i.ID, i.dg.InteractionRespond(i.i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Content:    b.Content,
@@ -120,6 +188,44 @@ func (i *InteractionCtx) Respond(msg MessageSend) error {
 	})
 }
 
+// respondEphemeral sends an ephemeral message, regardless of whether the interaction has been acknowledged
+func (i *InteractionCtx) respondEphemeral(msg MessageSend) error {
+	b, err := i.s.messageDg(msg)
+	if err != nil {
+		return err
+	}
+	if (i.acknowledged && !i.component) || i.responded { // Acknowledging a component doesn't respond, see Acknowledge
+		_, err = i.dg.FollowupMessageCreate(i.i, true, &discordgo.WebhookParams{
+			Content:    b.Content,
+			Files:      b.Files,
+			Embeds:     b.Embeds,
+			Components: b.Components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		})
+		return err
+	}
+	i.acknowledged = true
+	i.responded = true
+	return i.dg.InteractionRespond(i.i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    b.Content,
+			Files:      b.Files,
+			Embeds:     b.Embeds,
+			Components: b.Components,
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// respondError sends an ephemeral error message, logging if that fails
+func (i *InteractionCtx) respondError(content string) {
+	err := i.respondEphemeral(NewMessage(content))
+	if err != nil {
+		Logger.Println("Error responding to interaction", err)
+	}
+}
+
 func (i *InteractionCtx) Author() *discordgo.Member {
 	return i.i.Member
 }
@@ -127,31 +233,43 @@ func (i *InteractionCtx) Author() *discordgo.Member {
 func (i *InteractionCtx) Modal(m Modal) error {
 	comps := make([]discordgo.MessageComponent, len(m.Inputs))
 	for ind, inp := range m.Inputs {
+		id := inp.ID
+		if id == "" {
+			id = strconv.Itoa(ind)
+		}
 		comps[ind] = &discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.TextInput{
-					CustomID:    strconv.Itoa(ind),
+					CustomID:    id,
 					Label:       inp.Label,
 					Style:       discordgo.TextInputStyle(inp.Style),
 					Placeholder: inp.Placeholder,
 					Required:    inp.Required,
 					MinLength:   inp.MinLength,
 					MaxLength:   inp.MaxLength,
+					Value:       inp.Value,
 				},
 			},
 		}
 	}
 
-	i.s.lock.Lock()
-	i.s.modalHandlers[i.i.ID] = m.Handler
-	i.s.lock.Unlock()
+	customID := i.i.ID
+	if m.Handler != nil {
+		i.s.addModal(i.i.ID, m.Handler)
+	} else {
+		c, err := i.s.encodeCustomID(m.HandlerID, m.Params, time.Time{})
+		if err != nil {
+			return err
+		}
+		customID = c.String()
+	}
 
 	return i.dg.InteractionRespond(i.i, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
 			Title:      m.Title,
 			Components: comps,
-			CustomID:   i.i.ID,
+			CustomID:   customID,
 		},
 	})
 }
@@ -0,0 +1,269 @@
+package sevcord
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Custom IDs are formatted as "handler;meta;meta|params", where metadata added by sevcord is separated from the handler ID with metaSeperator. Handler IDs can't contain either seperator
+const metaSeperator = ";"
+
+const (
+	metaStored    = "k" // Params are a key in the state store
+	metaSignature = "s" // Followed by the signature of the rest of the custom ID
+	metaExpires   = "e" // Followed by the unix time the component expires at, in base 36
+)
+
+// signatureLength is the number of bytes of the HMAC kept in a custom ID
+const signatureLength = 12
+
+var errInvalidSignature = errors.New("sevcord: invalid custom id signature")
+
+type customID struct {
+	handler string
+	params  string
+	stored  bool
+	expires int64 // Unix time, 0 if it doesn't expire
+	sig     string
+}
+
+func parseCustomID(id string) customID {
+	parts := strings.SplitN(id, componentSeperator, 2)
+	if len(parts) == 1 {
+		parts = append(parts, "")
+	}
+	meta := strings.Split(parts[0], metaSeperator)
+	c := customID{handler: meta[0], params: parts[1]}
+	for _, v := range meta[1:] {
+		switch {
+		case v == metaStored:
+			c.stored = true
+
+		case strings.HasPrefix(v, metaSignature):
+			c.sig = strings.TrimPrefix(v, metaSignature)
+
+		case strings.HasPrefix(v, metaExpires):
+			c.expires, _ = strconv.ParseInt(strings.TrimPrefix(v, metaExpires), 36, 64)
+		}
+	}
+	return c
+}
+
+// head returns the handler ID with all metadata
+func (c customID) head() string {
+	out := c.handler
+	if c.stored {
+		out += metaSeperator + metaStored
+	}
+	if c.expires != 0 {
+		out += metaSeperator + metaExpires + strconv.FormatInt(c.expires, 36)
+	}
+	if c.sig != "" {
+		out += metaSeperator + metaSignature + c.sig
+	}
+	return out
+}
+
+func (c customID) String() string {
+	return c.head() + componentSeperator + c.params
+}
+
+// encodeCustomID moves params into the state store if they are too long to fit into a custom ID, and signs the custom ID if a signing key is set. Pass the zero time for expires if the component doesn't expire
+func (s *Sevcord) encodeCustomID(handler, params string, expires time.Time) (customID, error) {
+	s.lock.RLock()
+	key := s.signingKey
+	s.lock.RUnlock()
+
+	c := customID{handler: handler, params: params}
+	if !expires.IsZero() {
+		c.expires = expires.Unix()
+	}
+	if key != nil {
+		c.sig = strings.Repeat("0", base64.RawURLEncoding.EncodedLen(signatureLength)) // Make sure there is room for the signature
+	}
+	if utf8.RuneCountInString(c.String()) > MaxCustomIDLength {
+		c.stored = true
+		c.params = randomKey()
+		if length := utf8.RuneCountInString(c.String()); length > MaxCustomIDLength { // The handler ID is too long even without params
+			return c, fmt.Errorf("sevcord: custom id for handler %q is %d characters, max is %d", handler, length, MaxCustomIDLength)
+		}
+		if err := s.storeStateKey(c.params, params); err != nil {
+			return c, err
+		}
+	}
+	if key != nil {
+		c.sig = sign(key, c)
+	}
+	return c, nil
+}
+
+// decodeCustomID verifies and resolves the handler and params of a component's custom ID. exists is false if the component has expired or its params have expired from the state store
+func (s *Sevcord) decodeCustomID(id string) (c customID, exists bool, err error) {
+	s.lock.RLock()
+	key := s.signingKey
+	s.lock.RUnlock()
+
+	c = parseCustomID(id)
+	if key != nil {
+		if !hmac.Equal([]byte(c.sig), []byte(sign(key, c))) {
+			return c, false, errInvalidSignature
+		}
+		c.sig = ""
+	}
+	if c.expires != 0 && time.Now().Unix() >= c.expires {
+		return c, false, nil
+	}
+	if c.stored {
+		c.params, exists, err = s.loadState(c.params)
+		if err != nil || !exists {
+			return c, false, err
+		}
+		c.stored = false
+	}
+	return c, true, nil
+}
+
+// sign computes the signature of everything in a custom ID other than the signature itself
+func sign(key []byte, c customID) string {
+	c.sig = ""
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(c.String()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureLength])
+}
+
+// SetSigningKey enables signing of component custom IDs, so that component interactions with custom IDs that weren't created by the bot (for example, from modified clients) are rejected. The key should be kept secret and stay the same between restarts
+func (s *Sevcord) SetSigningKey(key []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.signingKey = key
+}
+
+// MessageDg validates a message and converts it to discordgo like sevcord does when responding, signing, storing params and adding expiry to component custom IDs. Use this instead of MessageSend.Dg to send messages with discordgo directly
+func (s *Sevcord) MessageDg(msg MessageSend) (*discordgo.MessageSend, error) {
+	return s.messageDg(msg)
+}
+
+// messageDg validates a message and converts it to discordgo, encoding the custom IDs of all of its components
+func (s *Sevcord) messageDg(msg MessageSend) (*discordgo.MessageSend, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	var expires time.Time
+	if msg.expiry > 0 {
+		expires = time.Now().Add(msg.expiry)
+	}
+	grid := make(componentGrid, len(msg.components))
+	for i, row := range msg.components {
+		grid[i] = make([]Component, len(row))
+		for j, comp := range row {
+			switch v := comp.(type) {
+			case *Button:
+				if v.Style == ButtonStyleLink {
+					grid[i][j] = v
+					continue
+				}
+				c, err := s.encodeCustomID(v.Handler, v.Params, expires)
+				if err != nil {
+					return nil, err
+				}
+				b := *v
+				b.Handler = c.head()
+				b.Params = c.params
+				grid[i][j] = &b
+
+			case *Select:
+				c, err := s.encodeCustomID(v.Handler, v.Params, expires)
+				if err != nil {
+					return nil, err
+				}
+				sel := *v
+				sel.Handler = c.head()
+				sel.Params = c.params
+				grid[i][j] = &sel
+
+			default:
+				grid[i][j] = comp
+			}
+		}
+	}
+	msg.components = grid
+	return msg.Dg(), nil
+}
+
+// scheduleExpiry disables or removes the components of a message once it expires. key identifies the message, see messageKeys. Sending a new version of the message through sevcord cancels the expiry, so that newer components aren't replaced
+func (s *Sevcord) scheduleExpiry(msg MessageSend, edit componentEditor, key string) {
+	if msg.expiry <= 0 || len(msg.components) == 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var timer *time.Timer
+	timer = time.AfterFunc(msg.expiry, func() {
+		s.lock.Lock()
+		current := s.expiryTimers[key] == timer
+		if current {
+			delete(s.expiryTimers, key)
+		}
+		s.lock.Unlock()
+		if !current { // The message has been updated since
+			return
+		}
+
+		var comps []discordgo.MessageComponent
+		if !msg.removeExpired {
+			msg.components = msg.components.disabled()
+			msg.expiry = 0
+			v, err := s.messageDg(msg)
+			if err != nil {
+				Logger.Println("Error disabling expired components", err)
+				return
+			}
+			comps = v.Components
+		} else {
+			comps = []discordgo.MessageComponent{}
+		}
+		if err := edit(comps); err != nil {
+			Logger.Println("Error disabling expired components", err)
+		}
+	})
+	if old, exists := s.expiryTimers[key]; exists {
+		old.Stop()
+	}
+	s.expiryTimers[key] = timer
+}
+
+// cancelExpiry stops the expiry of a message that is being updated, empty keys are ignored
+func (s *Sevcord) cancelExpiry(keys ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, key := range keys {
+		if timer, exists := s.expiryTimers[key]; exists && key != "" {
+			timer.Stop()
+			delete(s.expiryTimers, key)
+		}
+	}
+}
+
+// messageKeys gets the keys that a message's expiry can be stored with: its ID, and the ID of the interaction it responded to, since the ID of an interaction response isn't known when it is sent
+func messageKeys(m *discordgo.Message) []string {
+	if m == nil {
+		return nil
+	}
+	keys := []string{m.ID}
+	if m.Interaction != nil {
+		keys = append(keys, m.Interaction.ID)
+	}
+	return keys
+}
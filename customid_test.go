@@ -0,0 +1,153 @@
+package sevcord
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestBot(t *testing.T, key []byte) *Sevcord {
+	s, err := New("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != nil {
+		s.SetSigningKey(key)
+	}
+	return s
+}
+
+func TestCustomIDRoundTrip(t *testing.T) {
+	long := strings.Repeat("p", 2*MaxCustomIDLength)
+	tests := []struct {
+		name    string
+		key     []byte
+		handler string
+		params  string
+		expires time.Time
+		stored  bool
+	}{
+		{name: "plain", handler: "vote", params: "yes"},
+		{name: "empty params", handler: "vote"},
+		{name: "params with seperators", handler: "vote", params: "a|b;c"},
+		{name: "signed", key: []byte("secret"), handler: "vote", params: "yes"},
+		{name: "expires", handler: "vote", params: "yes", expires: time.Now().Add(time.Hour)},
+		{name: "signed and expires", key: []byte("secret"), handler: "vote", params: "yes", expires: time.Now().Add(time.Hour)},
+		{name: "stored", handler: "vote", params: long, stored: true},
+		{name: "signed, stored and expires", key: []byte("secret"), handler: "vote", params: long, expires: time.Now().Add(time.Hour), stored: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestBot(t, test.key)
+			c, err := s.encodeCustomID(test.handler, test.params, test.expires)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := c.String()
+			if n := len([]rune(id)); n > MaxCustomIDLength {
+				t.Fatalf("custom id is %d characters", n)
+			}
+			if c.stored != test.stored {
+				t.Errorf("stored is %v, want %v", c.stored, test.stored)
+			}
+			if (c.sig != "") != (test.key != nil) {
+				t.Errorf("signature %q with key %q", c.sig, test.key)
+			}
+
+			got, exists, err := s.decodeCustomID(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !exists {
+				t.Fatal("decoded custom id doesn't exist")
+			}
+			if got.handler != test.handler || got.params != test.params {
+				t.Errorf("decoded %q, %q, want %q, %q", got.handler, got.params, test.handler, test.params)
+			}
+		})
+	}
+}
+
+func TestCustomIDHandlerTooLong(t *testing.T) {
+	s := newTestBot(t, []byte("secret"))
+	if _, err := s.encodeCustomID(strings.Repeat("h", MaxCustomIDLength), "params", time.Time{}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestCustomIDExpired(t *testing.T) {
+	for _, key := range [][]byte{nil, []byte("secret")} {
+		s := newTestBot(t, key)
+		c, err := s.encodeCustomID("vote", "yes", time.Now().Add(-time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, exists, err := s.decodeCustomID(c.String())
+		if err != nil || exists {
+			t.Errorf("key %q: got exists %v, err %v, want an expired custom id", key, exists, err)
+		}
+	}
+}
+
+func TestCustomIDStoredMissing(t *testing.T) {
+	s := newTestBot(t, nil)
+	c, err := s.encodeCustomID("vote", strings.Repeat("p", 2*MaxCustomIDLength), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.params = randomKey() // A key that isn't in the store, like after a restart with the memory store
+	_, exists, err := s.decodeCustomID(c.String())
+	if err != nil || exists {
+		t.Errorf("got exists %v, err %v, want a missing custom id", exists, err)
+	}
+}
+
+func TestCustomIDTampered(t *testing.T) {
+	key := []byte("secret")
+	expires := time.Now().Add(time.Hour)
+	tests := map[string]func(c *customID){
+		"handler":           func(c *customID) { c.handler = "admin" },
+		"params":            func(c *customID) { c.params = "no" },
+		"expiry":            func(c *customID) { c.expires = time.Now().Add(24 * time.Hour).Unix() },
+		"expiry removed":    func(c *customID) { c.expires = 0 },
+		"stored added":      func(c *customID) { c.stored = true },
+		"signature removed": func(c *customID) { c.sig = "" },
+		"signature changed": func(c *customID) { c.sig = strings.Repeat("A", len(c.sig)) },
+	}
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newTestBot(t, key)
+			c, err := s.encodeCustomID("vote", "yes", expires)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tamper(&c)
+			if _, exists, err := s.decodeCustomID(c.String()); !errors.Is(err, errInvalidSignature) || exists {
+				t.Errorf("got exists %v, err %v, want %v", exists, err, errInvalidSignature)
+			}
+		})
+	}
+
+	// Editing the raw custom ID, with the expiry extended and moved after the signature
+	s := newTestBot(t, key)
+	c, err := s.encodeCustomID("vote", "yes", expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := c.handler + metaSeperator + metaSignature + c.sig + metaSeperator + metaExpires + strconv.FormatInt(c.expires+3600, 36) + componentSeperator + c.params
+	if _, _, err := s.decodeCustomID(raw); !errors.Is(err, errInvalidSignature) {
+		t.Errorf("moved expiry: got err %v, want %v", err, errInvalidSignature)
+	}
+
+	// Unsigned custom IDs aren't accepted once signing is enabled
+	unsigned := newTestBot(t, nil)
+	u, err := unsigned.encodeCustomID("vote", "yes", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.decodeCustomID(u.String()); !errors.Is(err, errInvalidSignature) {
+		t.Errorf("unsigned: got err %v, want %v", err, errInvalidSignature)
+	}
+}
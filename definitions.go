@@ -0,0 +1,168 @@
+package sevcord
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CommandDefinition defines a slash command or, if it has subcommands, a command group in a command definition file. Handlers are referenced by name and bound with LoadCommands
+type CommandDefinition struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Permissions *int                `json:"permissions,omitempty"` // Discord permissions bit mask, only allowed on top-level commands
+	Handler     string              `json:"handler,omitempty"`     // Name of the handler in CommandHandlers.Commands, only for commands
+	Options     []OptionDefinition  `json:"options,omitempty"`     // Only for commands
+	Subcommands []CommandDefinition `json:"subcommands,omitempty"` // Only for groups
+
+	LongDescription string   `json:"long_description,omitempty"` // Only for commands, see SlashCommand.Document
+	Examples        []string `json:"examples,omitempty"`         // Only for commands
+}
+
+// OptionDefinition defines an option of a command in a command definition file
+type OptionDefinition struct {
+	Name         string                  `json:"name"`
+	Description  string                  `json:"description"`
+	Kind         string                  `json:"kind"` // One of string, int, bool, user, channel, role, float or attachment
+	Required     bool                    `json:"required,omitempty"`
+	Choices      []Choice                `json:"choices,omitempty"`      // Values are strings, also for int and float options
+	Autocomplete string                  `json:"autocomplete,omitempty"` // Name of the handler in CommandHandlers.Autocomplete
+	Min          *float64                `json:"min,omitempty"`          // Min value, or min length for string options
+	Max          *float64                `json:"max,omitempty"`          // Max value, or max length for string options. Requires min
+	ChannelTypes []discordgo.ChannelType `json:"channel_types,omitempty"`
+}
+
+// CommandHandlers are the handlers that command definitions can reference by name
+type CommandHandlers struct {
+	Commands     map[string]SlashCommandHandler
+	Autocomplete map[string]AutocompleteHandler
+}
+
+// LoadCommands reads a JSON array of CommandDefinitions and binds them to the handlers. Unknown fields, unknown option kinds, missing handlers and commands that discord wouldn't accept are all reported in a *ValidationError
+func LoadCommands(r io.Reader, handlers CommandHandlers) ([]SlashCommandObject, error) {
+	var defs []CommandDefinition
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&defs); err != nil {
+		return nil, fmt.Errorf("sevcord: invalid command definitions: %w", err)
+	}
+	return BindCommands(defs, handlers)
+}
+
+// LoadCommandsFile is LoadCommands for a file
+func LoadCommandsFile(file string, handlers CommandHandlers) ([]SlashCommandObject, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cmds, err := LoadCommands(f, handlers)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return cmds, nil
+}
+
+// BindCommands builds commands from definitions, like LoadCommands
+func BindCommands(defs []CommandDefinition, handlers CommandHandlers) ([]SlashCommandObject, error) {
+	v := &ValidationError{}
+	out := make([]SlashCommandObject, len(defs))
+	names := make(map[string]struct{}, len(defs))
+	for i, def := range defs {
+		if _, exists := names[def.Name]; exists {
+			v.add("/"+def.Name, "is defined more than once")
+		}
+		names[def.Name] = struct{}{}
+		out[i] = def.bind(v, "/"+def.Name, handlers)
+	}
+	if len(v.Problems) > 0 { // Don't validate half-built commands, the problems would be repeated
+		return nil, v
+	}
+	for _, cmd := range out {
+		cmd.validate(v, "/"+cmd.name(), 0)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (d CommandDefinition) bind(v *ValidationError, path string, handlers CommandHandlers) SlashCommandObject {
+	if len(d.Subcommands) > 0 {
+		if d.Handler != "" || len(d.Options) > 0 || d.LongDescription != "" || len(d.Examples) > 0 {
+			v.add(path, "groups can't have a handler, options, long description or examples")
+		}
+		children := make([]SlashCommandObject, len(d.Subcommands))
+		for i, child := range d.Subcommands {
+			children[i] = child.bind(v, path+" "+child.Name, handlers)
+		}
+		return &SlashCommandGroup{
+			Name:        d.Name,
+			Description: d.Description,
+			Children:    children,
+			Permissions: d.Permissions,
+		}
+	}
+
+	handler, exists := handlers.Commands[d.Handler]
+	if d.Handler == "" {
+		v.add(path, "has no handler or subcommands")
+	} else if !exists {
+		v.add(path, "handler %q isn't registered", d.Handler)
+	}
+	opts := make([]Option, len(d.Options))
+	for i, opt := range d.Options {
+		opts[i] = opt.bind(v, path+" > "+opt.Name, handlers)
+	}
+	return &SlashCommand{
+		Name:        d.Name,
+		Description: d.Description,
+		Options:     opts,
+		Permissions: d.Permissions,
+		Handler:     handler,
+
+		LongDescription: d.LongDescription,
+		Examples:        d.Examples,
+	}
+}
+
+func (d OptionDefinition) bind(v *ValidationError, path string, handlers CommandHandlers) Option {
+	kind := OptionKind(-1)
+	for k, name := range optionKindNames {
+		if name == d.Kind {
+			kind = OptionKind(k)
+			break
+		}
+	}
+	if kind < 0 {
+		v.add(path, "unknown kind %q", d.Kind)
+	}
+
+	out := Option{
+		Name:         d.Name,
+		Description:  d.Description,
+		Kind:         kind,
+		Required:     d.Required,
+		Choices:      d.Choices,
+		MinVal:       d.Min,
+		ChannelTypes: d.ChannelTypes,
+	}
+	if d.Max != nil {
+		if d.Min == nil {
+			v.add(path, "max requires min")
+		}
+		out.MaxVal = *d.Max
+	}
+	if d.Autocomplete != "" {
+		handler, exists := handlers.Autocomplete[d.Autocomplete]
+		if !exists {
+			v.add(path, "autocomplete handler %q isn't registered", d.Autocomplete)
+		}
+		out.Autocomplete = handler
+	}
+	return out
+}
@@ -0,0 +1,240 @@
+package sevcord
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// DocsFormat is the format of the documentation created by GenerateDocs
+type DocsFormat int
+
+const (
+	DocsFormatMarkdown DocsFormat = iota
+	DocsFormatHTML                // An HTML fragment, to be put in the body of a page
+)
+
+// docsWriter writes documentation in a format, escaping all text passed to it
+type docsWriter interface {
+	heading(level int, text string)
+	paragraph(text string)
+	field(label, value string, code bool)
+	codeList(label string, items []string)
+	table(header []string, rows [][]string)
+}
+
+// GenerateDocs documents every command, subcommand, option and context menu registered with the bot, including guild commands, as they are sent to discord by Listen. Long descriptions and examples added with SlashCommand.Document are included
+func GenerateDocs(bot *Sevcord, format DocsFormat) (string, error) {
+	out := &strings.Builder{}
+	var w docsWriter
+	switch format {
+	case DocsFormatMarkdown:
+		w = &markdownDocs{out}
+
+	case DocsFormatHTML:
+		w = &htmlDocs{out}
+
+	default:
+		return "", fmt.Errorf("sevcord: unknown docs format %d", format)
+	}
+
+	bot.lock.RLock()
+	defer bot.lock.RUnlock()
+
+	if len(bot.commands) > 0 {
+		w.heading(1, "Commands")
+		docsCommands(w, bot.commands)
+	}
+	guilds := make([]string, 0, len(bot.guildCommands))
+	for guild, cmds := range bot.guildCommands {
+		if len(cmds) > 0 {
+			guilds = append(guilds, guild)
+		}
+	}
+	sort.Strings(guilds)
+	for _, guild := range guilds {
+		w.heading(1, "Commands in server "+guild)
+		docsCommands(w, bot.guildCommands[guild])
+	}
+
+	if len(bot.contextMenus) > 0 {
+		w.heading(1, "Context Menus")
+		menus := make([]*ContextMenuCommand, 0, len(bot.contextMenus))
+		for _, menu := range bot.contextMenus {
+			menus = append(menus, menu)
+		}
+		sort.Slice(menus, func(i, j int) bool {
+			if menus[i].Name != menus[j].Name {
+				return menus[i].Name < menus[j].Name
+			}
+			return menus[i].Kind < menus[j].Kind
+		})
+		rows := make([][]string, len(menus))
+		for i, menu := range menus {
+			perms := ""
+			if menu.Permissions != nil {
+				perms = strings.Join(PermissionNames(int64(*menu.Permissions)), ", ")
+			}
+			rows[i] = []string{menu.Name, menu.kindName(), perms}
+		}
+		w.table([]string{"Name", "Used on", "Permissions"}, rows)
+	}
+	return out.String(), nil
+}
+
+func docsCommands(w docsWriter, cmds map[string]SlashCommandObject) {
+	names := make([]string, 0, len(cmds))
+	for name := range cmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		docsCommand(w, cmds[name], name, 2, cmds[name].permissions())
+	}
+}
+
+// docsCommand documents a command or group, perms are the permissions of the top-level command and are only shown on it
+func docsCommand(w docsWriter, obj SlashCommandObject, path string, level int, perms *int64) {
+	w.heading(level, "/"+path)
+	if obj.isGroup() {
+		group := obj.(*SlashCommandGroup)
+		w.paragraph(group.Description)
+		if perms != nil {
+			w.field("Permissions", strings.Join(PermissionNames(*perms), ", "), false)
+		}
+		for _, child := range group.Children {
+			docsCommand(w, child, path+" "+child.name(), level+1, nil)
+		}
+		return
+	}
+
+	cmd := obj.(*SlashCommand)
+	w.paragraph(cmd.Description)
+	if cmd.LongDescription != "" {
+		w.paragraph(cmd.LongDescription)
+	}
+	w.field("Usage", commandUsage(path, cmd), true)
+	if perms != nil {
+		w.field("Permissions", strings.Join(PermissionNames(*perms), ", "), false)
+	}
+	if len(cmd.Options) > 0 {
+		rows := make([][]string, len(cmd.Options))
+		for i, opt := range cmd.Options {
+			required := "No"
+			if opt.Required {
+				required = "Yes"
+			}
+			details := make([]string, 0)
+			if len(opt.Choices) > 0 {
+				choices := make([]string, len(opt.Choices))
+				for i, choice := range opt.Choices {
+					choices[i] = choice.Name
+				}
+				details = append(details, "Choices: "+strings.Join(choices, ", "))
+			}
+			if limits := optionLimits(opt); limits != "" {
+				details = append(details, limits)
+			}
+			if opt.Autocomplete != nil {
+				details = append(details, "Autocompleted")
+			}
+			rows[i] = []string{opt.Name, opt.Kind.String(), required, opt.Description, strings.Join(details, "; ")}
+		}
+		w.table([]string{"Option", "Type", "Required", "Description", "Details"}, rows)
+	}
+	if len(cmd.Examples) > 0 {
+		w.codeList("Examples", cmd.Examples)
+	}
+}
+
+type markdownDocs struct {
+	out *strings.Builder
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;", "|", `\|`, "#", `\#`)
+
+func (m *markdownDocs) heading(level int, text string) {
+	fmt.Fprintf(m.out, "%s %s\n\n", strings.Repeat("#", level), markdownEscaper.Replace(text))
+}
+
+func (m *markdownDocs) paragraph(text string) {
+	m.out.WriteString(markdownEscaper.Replace(text) + "\n\n")
+}
+
+func (m *markdownDocs) field(label, value string, code bool) {
+	if code {
+		value = "`" + strings.ReplaceAll(value, "`", "'") + "`"
+	} else {
+		value = markdownEscaper.Replace(value)
+	}
+	fmt.Fprintf(m.out, "**%s:** %s\n\n", label, value)
+}
+
+func (m *markdownDocs) codeList(label string, items []string) {
+	fmt.Fprintf(m.out, "**%s:**\n\n", label)
+	for _, item := range items {
+		m.out.WriteString("- `" + strings.ReplaceAll(item, "`", "'") + "`\n")
+	}
+	m.out.WriteString("\n")
+}
+
+func (m *markdownDocs) table(header []string, rows [][]string) {
+	m.out.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	m.out.WriteString(strings.Repeat("| --- ", len(header)) + "|\n")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.ReplaceAll(markdownEscaper.Replace(cell), "\n", " ")
+		}
+		m.out.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	m.out.WriteString("\n")
+}
+
+type htmlDocs struct {
+	out *strings.Builder
+}
+
+func (h *htmlDocs) heading(level int, text string) {
+	if level > 6 {
+		level = 6
+	}
+	fmt.Fprintf(h.out, "<h%d>%s</h%d>\n", level, html.EscapeString(text), level)
+}
+
+func (h *htmlDocs) paragraph(text string) {
+	h.out.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(text), "\n", "<br>") + "</p>\n")
+}
+
+func (h *htmlDocs) field(label, value string, code bool) {
+	value = html.EscapeString(value)
+	if code {
+		value = "<code>" + value + "</code>"
+	}
+	fmt.Fprintf(h.out, "<p><strong>%s:</strong> %s</p>\n", html.EscapeString(label), value)
+}
+
+func (h *htmlDocs) codeList(label string, items []string) {
+	fmt.Fprintf(h.out, "<p><strong>%s:</strong></p>\n<ul>\n", html.EscapeString(label))
+	for _, item := range items {
+		h.out.WriteString("<li><code>" + html.EscapeString(item) + "</code></li>\n")
+	}
+	h.out.WriteString("</ul>\n")
+}
+
+func (h *htmlDocs) table(header []string, rows [][]string) {
+	h.out.WriteString("<table>\n<thead><tr>")
+	for _, cell := range header {
+		h.out.WriteString("<th>" + html.EscapeString(cell) + "</th>")
+	}
+	h.out.WriteString("</tr></thead>\n<tbody>\n")
+	for _, row := range rows {
+		h.out.WriteString("<tr>")
+		for _, cell := range row {
+			h.out.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+		}
+		h.out.WriteString("</tr>\n")
+	}
+	h.out.WriteString("</tbody>\n</table>\n")
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"math/rand"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/Nv7-Github/sevcord/v2"
@@ -29,19 +32,22 @@ func main() {
 		return true
 	})
 	// Ping + button example
-	bot.RegisterSlashCommand(sevcord.NewSlashCommand("ping", "Is the bot ok? + Button demo", func(ctx sevcord.Ctx, params []any) {
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("ping", "Is the bot ok? + Button demo", func(ctx sevcord.Ctx, params []any) {
 		ctx.Acknowledge()
 		msg := ""
 		if params[0] != nil {
 			msg = " " + params[0].(string)
 		}
-		ctx.Respond(sevcord.NewMessage("Pong!" + msg).
-			AddComponentRow(sevcord.NewButton("Click me!", sevcord.ButtonStylePrimary, "click", ctx.Author().User.ID)))
+		ctx.Respond(sevcord.NewMessage("Pong!"+msg).
+			AddComponentRow(sevcord.NewButton("Click me!", sevcord.ButtonStylePrimary, "click", ctx.Author().User.ID)).
+			Expire(10*time.Minute, false))
 	}, sevcord.NewOption("echo", "Echoed in the response", sevcord.OptionKindString, false).AutoComplete(func(ctx sevcord.Ctx, params any) []sevcord.Choice {
 		return []sevcord.Choice{sevcord.NewChoice("Hello", "Hello"), sevcord.NewChoice("World", "World")}
-	})))
+	}))); err != nil {
+		panic(err)
+	}
 	// Select menu example
-	bot.RegisterSlashCommand(sevcord.NewSlashCommand("select", "Select menu demo", func(ctx sevcord.Ctx, params []any) {
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("select", "Select menu demo", func(ctx sevcord.Ctx, params []any) {
 		ctx.Acknowledge()
 		ctx.Respond(sevcord.NewMessage("Check out these select menus").
 			AddComponentRow(
@@ -54,12 +60,17 @@ func main() {
 					SetRange(0, 3), // Allows users to select unlimited instead of default of 1
 			),
 		)
-	}))
-	bot.RegisterSlashCommand(sevcord.NewSlashCommand("auto-select", "Auto-populated select menu demo", func(ctx sevcord.Ctx, a []any) {
+	})); err != nil {
+		panic(err)
+	}
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("auto-select", "Auto-populated select menu demo", func(ctx sevcord.Ctx, a []any) {
 		ctx.Acknowledge()
 		ctx.Respond(sevcord.NewMessage("Check out these auto-populated select menus").
 			AddComponentRow(
-				sevcord.NewSelect("User menu", "user_select", "User").SetKind(sevcord.SelectKindUser).SetRange(0, 25),
+				sevcord.NewSelect("User menu", "user_select", "User").
+					SetKind(sevcord.SelectKindUser).
+					DefaultValues(sevcord.NewSelectDefaultUser(ctx.Author().User.ID)).
+					SetRange(0, 25),
 			).
 			AddComponentRow(
 				sevcord.NewSelect("Role menu", "role_select", "Role").
@@ -83,18 +94,122 @@ func main() {
 					ChannelMenuFilter(discordgo.ChannelTypeGuildVoice).
 					SetRange(0, 25),
 			))
-	}))
+	})); err != nil {
+		panic(err)
+	}
 	// Modal example
-	bot.RegisterSlashCommand(sevcord.NewSlashCommand("modal", "Modal demo", func(ctxV sevcord.Ctx, params []any) {
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("modal", "Modal demo", func(ctxV sevcord.Ctx, params []any) {
 		ctx := ctxV.(*sevcord.InteractionCtx)
-		ctx.Modal(sevcord.NewModal("Modal", func(ctx sevcord.Ctx, values []string) {
+		ctx.Modal(sevcord.NewModal("Modal", func(ctx sevcord.Ctx, values sevcord.ModalValues) {
 			ctx.Acknowledge()
-			ctx.Respond(sevcord.NewMessage(fmt.Sprintf("You entered: `%v`", values[0])))
+			ctx.Respond(sevcord.NewMessage(fmt.Sprintf("You entered: `%v` and `%v`", values["text"], values["paragraph"])))
 		}).
-			Input(sevcord.NewModalInput("text", "Text input", sevcord.ModalInputStyleSentence, 240)).
-			Input(sevcord.NewModalInput("paragraph", "Paragraph input", sevcord.ModalInputStyleParagraph, 2400)),
+			Input(sevcord.NewModalInput("text", "Text input", sevcord.ModalInputStyleSentence, 240).SetID("text").SetValue("Prefilled")).
+			Input(sevcord.NewModalInput("paragraph", "Paragraph input", sevcord.ModalInputStyleParagraph, 2400).SetID("paragraph")),
 		)
-	}))
+	})); err != nil {
+		panic(err)
+	}
+	// Confirmation example
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("delete", "Confirmation demo", func(ctx sevcord.Ctx, params []any) {
+		sevcord.Confirm(ctx, "Are you sure you want to delete everything?", sevcord.ConfirmOptions{
+			ConfirmLabel: "Delete",
+			OnConfirm: func(ctx sevcord.Ctx) {
+				ctx.Respond(sevcord.NewMessage("Deleted everything!"))
+			},
+			OnCancel: func(ctx sevcord.Ctx) {
+				ctx.Respond(sevcord.NewMessage("Cancelled"))
+			},
+		})
+	})); err != nil {
+		panic(err)
+	}
+	// Wizard example
+	prompt := func(text string) sevcord.WizardPrompt {
+		return func(sess *sevcord.WizardSession) sevcord.MessageSend { return sevcord.NewMessage(text) }
+	}
+	onboarding := sevcord.NewWizard(func(ctx sevcord.Ctx, sess *sevcord.WizardSession) {
+		ctx.Respond(sevcord.NewMessage(fmt.Sprintf("Welcome %s! You like %s and %v", sess.ModalValues("name")["name"], sess.String("kind"), sess.Strings("topics"))))
+	},
+		sevcord.NewModalStep("name", prompt("What's your name?"), "Enter name", sevcord.NewModal("Name", nil).
+			Input(sevcord.NewModalInput("Name", "Your name", sevcord.ModalInputStyleSentence, 32).SetID("name"))),
+		sevcord.NewButtonStep("kind", prompt("Cats or dogs?"),
+			sevcord.NewButton("Cats", sevcord.ButtonStylePrimary, "", "cats"),
+			sevcord.NewButton("Dogs", sevcord.ButtonStylePrimary, "", "dogs"),
+		),
+		sevcord.NewSelectStep("topics", prompt("What topics are you interested in?"), sevcord.NewSelect("Topics", "", "").
+			Option(sevcord.NewSelectOption("Games", "Video games", "games")).
+			Option(sevcord.NewSelectOption("Music", "All kinds of music", "music")).
+			SetRange(1, 2)),
+	)
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("onboard", "Wizard demo", func(ctx sevcord.Ctx, params []any) {
+		onboarding.Start(ctx)
+	})); err != nil {
+		panic(err)
+	}
+	// Paginator example
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("pages", "Paginator demo", func(ctx sevcord.Ctx, params []any) {
+		sevcord.NewDynamicPaginator(50, func(page int) sevcord.MessageSend {
+			return sevcord.NewMessage("").AddEmbed(sevcord.NewEmbed().
+				Title(fmt.Sprintf("Page %d", page+1)).
+				Description(fmt.Sprintf("%d squared is %d", page+1, (page+1)*(page+1))))
+		}).JumpSelect(true).OnlyAuthor(true).Send(ctx)
+	})); err != nil {
+		panic(err)
+	}
+	// Await example
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("coinflip", "Await component demo", func(ctx sevcord.Ctx, params []any) {
+		ev, err := sevcord.AwaitComponent(context.Background(), ctx, sevcord.NewMessage("Heads or tails?").
+			AddComponentRow(
+				sevcord.NewButton("Heads", sevcord.ButtonStylePrimary, "coin", "heads"),
+				sevcord.NewButton("Tails", sevcord.ButtonStylePrimary, "coin", "tails"),
+			), sevcord.FilterUsers(ctx.Author().User.ID), time.Minute)
+		if err != nil {
+			return
+		}
+		result := []string{"heads", "tails"}[rand.Intn(2)]
+		if ev.Params == result {
+			ev.Ctx.Respond(sevcord.NewMessage("It was " + result + ", you win!"))
+		} else {
+			ev.Ctx.Respond(sevcord.NewMessage("It was " + result + ", you lose!"))
+		}
+	})); err != nil {
+		panic(err)
+	}
+	// Typed modal example
+	type profile struct {
+		Name    string        `modal:"label=Name;placeholder=Your name;max=32;required"`
+		Age     int           `modal:"label=Age;placeholder=18;max=3;required"`
+		Website *url.URL      `modal:"label=Website;placeholder=https://example.com"`
+		Remind  time.Duration `modal:"label=Remind me in;placeholder=1h30m"`
+	}
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("profile", "Typed modal demo", func(ctxV sevcord.Ctx, params []any) {
+		ctx := ctxV.(*sevcord.InteractionCtx)
+		modal, err := sevcord.NewTypedModal("Profile", profile{Name: ctx.Author().User.Username}, func(ctx sevcord.Ctx, p profile) {
+			ctx.Respond(sevcord.NewMessage(fmt.Sprintf("%s is %d years old, website: %v, reminding in %s", p.Name, p.Age, p.Website, p.Remind)))
+		})
+		if err != nil {
+			panic(err)
+		}
+		ctx.Modal(modal)
+	})); err != nil {
+		panic(err)
+	}
+	// Named modal example, works even after the bot restarts
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("feedback", "Named modal demo", func(ctx sevcord.Ctx, params []any) {
+		ctx.Respond(sevcord.NewMessage("Have feedback?").
+			AddComponentRow(sevcord.NewButton("Give feedback", sevcord.ButtonStyleSecondary, "feedback", "")))
+	})); err != nil {
+		panic(err)
+	}
+	bot.AddButtonHandler("feedback", func(ctxV sevcord.Ctx, params string) {
+		ctx := ctxV.(*sevcord.InteractionCtx)
+		ctx.Modal(sevcord.NewNamedModal("Feedback", "feedback", ctx.Author().User.ID).
+			Input(sevcord.NewModalInput("Feedback", "What do you think?", sevcord.ModalInputStyleParagraph, 1000).SetID("feedback")))
+	})
+	bot.AddModalHandler("feedback", func(ctx sevcord.Ctx, params string, values sevcord.ModalValues) {
+		ctx.Respond(sevcord.NewMessage(fmt.Sprintf("<@%s> said: `%s`", params, values["feedback"])))
+	})
 	// Button example handler
 	bot.AddButtonHandler("click", func(ctx sevcord.Ctx, params string) {
 		// Uses params to see whether author is pressing
@@ -106,10 +221,45 @@ func main() {
 				AddComponentRow(sevcord.NewButton("Click me!", sevcord.ButtonStylePrimary, "click", params)))
 		}
 	})
+	// Typed button example
+	type counter struct {
+		Count int
+		Owner string
+	}
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("counter", "Typed button demo", func(ctx sevcord.Ctx, params []any) {
+		btn, err := sevcord.NewTypedButton("Count: 0", sevcord.ButtonStylePrimary, "counter", counter{0, ctx.Author().User.ID})
+		if err != nil {
+			panic(err)
+		}
+		ctx.Respond(sevcord.NewMessage("Click to count!").AddComponentRow(btn))
+	})); err != nil {
+		panic(err)
+	}
+	sevcord.AddTypedButtonHandler(bot, "counter", func(ctx sevcord.Ctx, params counter) {
+		params.Count++
+		btn, err := sevcord.NewTypedButton(fmt.Sprintf("Count: %d", params.Count), sevcord.ButtonStylePrimary, "counter", params)
+		if err != nil {
+			panic(err)
+		}
+		ctx.Respond(sevcord.NewMessage("Click to count!").AddComponentRow(btn))
+	})
 	// Select menu example handler
 	selectHandler := func(ctx sevcord.Ctx, params string, options []string) {
 		ctx.Acknowledge() // That way it makes a new ephemeral message instead of updating the original
-		ctx.Respond(sevcord.NewMessage(fmt.Sprintf("You Selected: `%v` (Select Type: **%s**)", options, params)))
+		names := make([]string, 0)
+		if resolved := ctx.(*sevcord.InteractionCtx).SelectResolved(); resolved != nil {
+			for _, v := range resolved.Mentionables() {
+				if v.IsRole {
+					names = append(names, "@"+v.Role.Name)
+				} else {
+					names = append(names, v.User.Username)
+				}
+			}
+			for _, v := range resolved.Channels() {
+				names = append(names, "#"+v.Name)
+			}
+		}
+		ctx.Respond(sevcord.NewMessage(fmt.Sprintf("You Selected: `%v` %v (Select Type: **%s**)", options, names, params)))
 	}
 	bot.AddSelectHandler("select", selectHandler)
 	bot.AddSelectHandler("user_select", selectHandler)
@@ -124,5 +274,82 @@ func main() {
 			ctx.Respond(sevcord.NewMessage("Pong!"))
 		}
 	})
-	bot.Listen()
+	// Runtime registration example
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("toggle-secret", "Adds or removes the /secret command in this server", func(ctx sevcord.Ctx, params []any) {
+		err := bot.UnregisterGuildSlashCommand(ctx.Guild(), "secret")
+		if err == nil {
+			ctx.Respond(sevcord.NewMessage("Removed /secret"))
+			return
+		}
+		err = bot.RegisterGuildSlashCommand(ctx.Guild(), sevcord.NewSlashCommand("secret", "A secret command", func(ctx sevcord.Ctx, params []any) {
+			ctx.Respond(sevcord.NewMessage("You found the secret!"))
+		}))
+		if err != nil {
+			ctx.Respond(sevcord.NewMessage("Error: " + err.Error()))
+			return
+		}
+		ctx.Respond(sevcord.NewMessage("Added /secret"))
+	})); err != nil {
+		panic(err)
+	}
+	// Routing example
+	if err := bot.RegisterSlashCommand(sevcord.NewSlashCommand("shop", "Handler routing demo", func(ctx sevcord.Ctx, params []any) {
+		ctx.Respond(sevcord.NewMessage("What do you want to buy?").
+			AddComponentRow(
+				sevcord.NewButton("Apple", sevcord.ButtonStylePrimary, "shop/apple/buy", ""),
+				sevcord.NewButton("Banana", sevcord.ButtonStylePrimary, "shop/banana/buy", ""),
+			))
+	})); err != nil {
+		panic(err)
+	}
+	bot.AddButtonHandler("shop/:item/buy", func(ctx sevcord.Ctx, params string) {
+		ctx.Respond(sevcord.NewMessage("You bought: " + ctx.(*sevcord.InteractionCtx).RouteParams()["item"]))
+	})
+	// Context menu example
+	if err := bot.RegisterContextMenu(sevcord.NewContextMenu(sevcord.ContextMenuKindUser, "Wave", func(ctx sevcord.Ctx, user string) {
+		ctx.Respond(sevcord.NewMessage("👋 <@" + user + ">"))
+	})); err != nil {
+		panic(err)
+	}
+	// Help example
+	if err := bot.RegisterHelpCommand(); err != nil {
+		panic(err)
+	}
+	// Module example
+	if err := bot.Use(&pollModule{}); err != nil {
+		panic(err)
+	}
+	if err := bot.Listen(); err != nil {
+		panic(err)
+	}
 }
+
+// pollModule is an example module
+type pollModule struct {
+	lock  *sync.Mutex // Handlers run concurrently
+	votes map[string]int
+}
+
+func (p *pollModule) Name() string { return "poll" }
+
+func (p *pollModule) OnLoad(m *sevcord.ModuleRegistry) error {
+	p.lock = &sync.Mutex{}
+	p.votes = make(map[string]int)
+	m.RegisterSlashCommand(sevcord.NewSlashCommand("poll", "Module demo", func(ctx sevcord.Ctx, params []any) {
+		ctx.Respond(sevcord.NewMessage("Yes or no?").AddComponentRow(
+			sevcord.NewButton("Yes", sevcord.ButtonStyleSuccess, m.ID("vote"), "yes"),
+			sevcord.NewButton("No", sevcord.ButtonStyleDanger, m.ID("vote"), "no"),
+		))
+	}))
+	m.AddButtonHandler("vote", func(ctx sevcord.Ctx, params string) {
+		p.lock.Lock()
+		p.votes[params]++
+		msg := fmt.Sprintf("Yes: %d, No: %d", p.votes["yes"], p.votes["no"])
+		p.lock.Unlock()
+		ctx.Acknowledge()
+		ctx.Respond(sevcord.NewMessage(msg))
+	})
+	return nil
+}
+
+func (p *pollModule) OnUnload(m *sevcord.ModuleRegistry) error { return nil }
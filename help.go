@@ -0,0 +1,294 @@
+package sevcord
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// helpPageSize is how many commands are shown on each page of /help
+const helpPageSize = 10
+
+// helpEntry is a command shown by /help
+type helpEntry struct {
+	path        string // Full path without the slash, like "config set"
+	group       string // Top-level group, empty if the command isn't in a group
+	cmd         *SlashCommand
+	permissions *int64 // Permissions of the top-level command
+}
+
+// RegisterHelpCommand registers a global /help command that lists the commands and context menus the member can use, grouped by their top-level group, and shows the details of a command when its path is passed
+func (s *Sevcord) RegisterHelpCommand() error {
+	return s.RegisterSlashCommand(NewSlashCommand("help", "Shows the commands you can use", s.help,
+		NewOption("command", "The command to show the details of", OptionKindString, false).AutoComplete(s.helpAutocomplete),
+	).AllowText())
+}
+
+// walkCommands calls fn with every command in a command tree and its path
+func walkCommands(obj SlashCommandObject, path string, fn func(path string, cmd *SlashCommand)) {
+	if !obj.isGroup() {
+		fn(path, obj.(*SlashCommand))
+		return
+	}
+	for _, child := range obj.(*SlashCommandGroup).Children {
+		walkCommands(child, path+" "+child.name(), fn)
+	}
+}
+
+// helpEntries gets the commands and context menus that the author of ctx can use, sorted by path
+func (s *Sevcord) helpEntries(ctx Ctx) ([]helpEntry, []*ContextMenuCommand) {
+	perms, inGuild, err := ctxPermissions(ctx)
+	if err != nil { // Show every command, permissions are still checked when they are used
+		Logger.Println("Error getting permissions", err)
+		inGuild = false
+	}
+	visible := func(required *int64) bool {
+		return !inGuild || hasPermissions(perms, required)
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	cmds := make(map[string]SlashCommandObject, len(s.commands))
+	for name, cmd := range s.commands {
+		cmds[name] = cmd
+	}
+	for name, cmd := range s.guildCommands[ctx.Guild()] {
+		cmds[name] = cmd
+	}
+
+	entries := make([]helpEntry, 0, len(cmds))
+	for name, obj := range cmds {
+		if !visible(obj.permissions()) {
+			continue
+		}
+		group := ""
+		if obj.isGroup() {
+			group = name
+		}
+		walkCommands(obj, name, func(path string, cmd *SlashCommand) {
+			entries = append(entries, helpEntry{path: path, group: group, cmd: cmd, permissions: obj.permissions()})
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+
+	menus := make([]*ContextMenuCommand, 0, len(s.contextMenus))
+	for _, menu := range s.contextMenus {
+		var required *int64
+		if menu.Permissions != nil {
+			v := int64(*menu.Permissions)
+			required = &v
+		}
+		if visible(required) {
+			menus = append(menus, menu)
+		}
+	}
+	sort.Slice(menus, func(i, j int) bool {
+		if menus[i].Name != menus[j].Name {
+			return menus[i].Name < menus[j].Name
+		}
+		return menus[i].Kind < menus[j].Kind
+	})
+	return entries, menus
+}
+
+// commandUsage gets how a command is used, like "/config set <key> [value]"
+func commandUsage(path string, cmd *SlashCommand) string {
+	out := "/" + path
+	for _, opt := range cmd.Options {
+		if opt.Required {
+			out += " <" + opt.Name + ">"
+		} else {
+			out += " [" + opt.Name + "]"
+		}
+	}
+	return out
+}
+
+func (c *ContextMenuCommand) kindName() string {
+	if c.Kind == ContextMenuKindUser {
+		return "User"
+	}
+	return "Message"
+}
+
+func (s *Sevcord) help(ctx Ctx, args []any) {
+	entries, menus := s.helpEntries(ctx)
+	if args[0] != nil {
+		path := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(args[0].(string), "/")))
+		for _, entry := range entries {
+			if entry.path == path {
+				if err := ctx.Respond(NewMessage("").AddEmbed(helpDetail(entry))); err != nil {
+					Logger.Println("Error responding to help", err)
+				}
+				return
+			}
+		}
+		if err := ctx.Respond(NewMessage("Command `/" + path + "` doesn't exist.")); err != nil {
+			Logger.Println("Error responding to help", err)
+		}
+		return
+	}
+
+	// Group commands by top-level group, with commands that aren't in a group first
+	groups := make([]string, 0)
+	grouped := make(map[string][]helpEntry)
+	for _, entry := range entries {
+		if _, exists := grouped[entry.group]; !exists {
+			groups = append(groups, entry.group)
+		}
+		grouped[entry.group] = append(grouped[entry.group], entry)
+	}
+	sort.Strings(groups)
+
+	pages := make([]MessageSend, 0)
+	for _, group := range groups {
+		title := "Commands"
+		if group != "" {
+			title = "/" + group
+		}
+		list := grouped[group]
+		for start := 0; start < len(list); start += helpPageSize {
+			end := start + helpPageSize
+			if end > len(list) {
+				end = len(list)
+			}
+			lines := make([]string, end-start)
+			for i, entry := range list[start:end] {
+				lines[i] = "`" + commandUsage(entry.path, entry.cmd) + "` - " + entry.cmd.Description
+			}
+			pageTitle := title
+			if len(list) > helpPageSize {
+				pageTitle += fmt.Sprintf(" (%d/%d)", start/helpPageSize+1, (len(list)+helpPageSize-1)/helpPageSize)
+			}
+			pages = append(pages, NewMessage("").AddEmbed(NewEmbed().
+				Title(pageTitle).
+				Description(strings.Join(lines, "\n")).
+				Footer("Use /help command:<command> to see the details of a command", ""),
+			))
+		}
+	}
+	if len(menus) > 0 {
+		lines := make([]string, len(menus))
+		for i, menu := range menus {
+			lines[i] = "**" + menu.Name + "** - " + menu.kindName() + " context menu"
+		}
+		pages = append(pages, NewMessage("").AddEmbed(NewEmbed().
+			Title("Context Menus").
+			Description(strings.Join(lines, "\n")),
+		))
+	}
+
+	if err := NewPaginator(pages...).JumpSelect(true).OnlyAuthor(true).Send(ctx); err != nil {
+		Logger.Println("Error responding to help", err)
+	}
+}
+
+// helpDetail creates the embed with the details of a command. Long descriptions, examples and option details are shortened to fit into discord's embed limits
+func helpDetail(entry helpEntry) EmbedBuilder {
+	title := "/" + entry.path
+	tail := "\n\n**Usage:** `" + commandUsage(entry.path, entry.cmd) + "`"
+	if entry.permissions != nil {
+		tail += "\n**Permissions:** " + strings.Join(PermissionNames(*entry.permissions), ", ")
+	}
+	if len(entry.cmd.Examples) > 0 {
+		tail += "\n**Examples:**"
+		for i, example := range entry.cmd.Examples {
+			line := "\n`" + example + "`"
+			if utf8.RuneCountInString(tail+line) > maxEmbedDescription/2 { // Leave room for the description
+				tail += fmt.Sprintf("\n*%d more*", len(entry.cmd.Examples)-i)
+				break
+			}
+			tail += line
+		}
+	}
+
+	// Options, each gets an equal share of half of the embed if they don't all fit
+	fieldMax := maxEmbedFieldValue
+	if len(entry.cmd.Options) > 0 && maxEmbedTotal/2/len(entry.cmd.Options) < fieldMax {
+		fieldMax = maxEmbedTotal / 2 / len(entry.cmd.Options)
+	}
+	type field struct{ name, value string }
+	fields := make([]field, len(entry.cmd.Options))
+	total := utf8.RuneCountInString(title)
+	for i, opt := range entry.cmd.Options {
+		required := "optional"
+		if opt.Required {
+			required = "required"
+		}
+		lines := []string{opt.Description, "*" + opt.Kind.String() + ", " + required + "*"}
+		if len(opt.Choices) > 0 {
+			choices := make([]string, len(opt.Choices))
+			for i, choice := range opt.Choices {
+				choices[i] = "`" + choice.Name + "`"
+			}
+			lines = append(lines, "Choices: "+strings.Join(choices, ", "))
+		}
+		if limits := optionLimits(opt); limits != "" {
+			lines = append(lines, limits)
+		}
+		fields[i] = field{opt.Name, truncate(strings.Join(lines, "\n"), fieldMax)}
+		total += utf8.RuneCountInString(fields[i].name) + utf8.RuneCountInString(fields[i].value)
+	}
+
+	desc := entry.cmd.Description
+	if entry.cmd.LongDescription != "" {
+		desc = entry.cmd.LongDescription
+	}
+	room := maxEmbedDescription
+	if maxEmbedTotal-total < room {
+		room = maxEmbedTotal - total
+	}
+	desc = truncate(desc, room-utf8.RuneCountInString(tail)) + tail
+
+	e := NewEmbed().Title(title).Description(desc)
+	for _, f := range fields {
+		e = e.AddField(f.name, f.value, false)
+	}
+	return e
+}
+
+// truncate shortens text to at most max characters, ending it with an ellipsis if it was cut off
+func truncate(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	if max <= 0 {
+		return ""
+	}
+	return string([]rune(text)[:max-1]) + "…"
+}
+
+// optionLimits describes the min and max of an option, returning an empty string if it has none
+func optionLimits(opt Option) string {
+	if opt.MinVal == nil {
+		return ""
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	name := "Value"
+	if opt.Kind == OptionKindString {
+		name = "Length"
+	}
+	if opt.MaxVal == 0 {
+		return name + ": at least " + format(*opt.MinVal)
+	}
+	return name + ": " + format(*opt.MinVal) + " to " + format(opt.MaxVal)
+}
+
+func (s *Sevcord) helpAutocomplete(ctx Ctx, val any) []Choice {
+	query, _ := val.(string)
+	query = strings.ToLower(strings.TrimPrefix(query, "/"))
+	entries, _ := s.helpEntries(ctx)
+	out := make([]Choice, 0, maxChoices)
+	for _, entry := range entries {
+		if strings.Contains(entry.path, query) {
+			out = append(out, NewChoice("/"+entry.path, entry.path))
+			if len(out) == maxChoices {
+				break
+			}
+		}
+	}
+	return out
+}
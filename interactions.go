@@ -1,6 +1,8 @@
 package sevcord
 
 import (
+	"encoding/json"
+	"errors"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
@@ -19,7 +21,8 @@ func (s *Sevcord) checkMiddleware(ctx Ctx, command string) bool {
 	return true
 }
 
-func (s *Sevcord) interactionHandler(dg *discordgo.Session, i *discordgo.InteractionCreate) {
+// interactionHandler handles an interaction, raw is the raw JSON of the interaction for data that discordgo doesn't parse
+func (s *Sevcord) interactionHandler(dg *discordgo.Session, i *discordgo.InteractionCreate, raw json.RawMessage) {
 	ctx := &InteractionCtx{
 		dg: dg,
 		i:  i.Interaction,
@@ -29,15 +32,31 @@ func (s *Sevcord) interactionHandler(dg *discordgo.Session, i *discordgo.Interac
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand, discordgo.InteractionApplicationCommandAutocomplete:
 		dat := i.ApplicationCommandData()
-		s.lock.RLock()
-		v, exists := s.commands[dat.Name]
-		s.lock.RUnlock()
+		if kind := commandType(raw); kind == discordgo.UserApplicationCommand || kind == discordgo.MessageApplicationCommand {
+			s.lock.RLock()
+			cmd, exists := s.contextMenus[contextMenuKey{ContextMenuKind(kind), dat.Name}]
+			s.lock.RUnlock()
+			if !exists {
+				s.unknownInteraction(ctx, UnknownInteractionCommand, dat.Name)
+				return
+			}
+			if s.checkMiddleware(ctx, dat.Name) {
+				cmd.Handler(ctx, dat.TargetID)
+			}
+			return
+		}
+		v, exists := s.findCommand(i.GuildID, dat.Name)
+		autocomplete := i.Type == discordgo.InteractionApplicationCommandAutocomplete
 		if !exists {
+			if !autocomplete {
+				s.unknownInteraction(ctx, UnknownInteractionCommand, dat.Name)
+			}
 			return
 		}
 		cmdOpts := dat.Options
 		if v.isGroup() {
 			var opt *discordgo.ApplicationCommandInteractionDataOption
+			path := dat.Name
 			for v.isGroup() {
 				if opt == nil {
 					opt = dat.Options[0]
@@ -46,18 +65,27 @@ func (s *Sevcord) interactionHandler(dg *discordgo.Session, i *discordgo.Interac
 					opt = opt.Options[0]
 				}
 
+				found := false
 				for _, val := range v.(*SlashCommandGroup).Children {
 					if val.name() == opt.Name {
 						v = val
 						cmdOpts = opt.Options
+						found = true
 						break
 					}
 				}
+				path += " " + opt.Name
+				if !found {
+					if !autocomplete {
+						s.unknownInteraction(ctx, UnknownInteractionCommand, path)
+					}
+					return
+				}
 			}
 		}
 
 		// If autocomplete
-		if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		if autocomplete {
 			for _, opt := range cmdOpts {
 				if opt.Focused {
 					for _, vopt := range v.(*SlashCommand).Options {
@@ -98,51 +126,135 @@ func (s *Sevcord) interactionHandler(dg *discordgo.Session, i *discordgo.Interac
 		}
 
 		// Check midleware
-		if s.checkMiddleware(ctx, dat.Name) {
+		s.lock.RLock()
+		module, inModule := s.commandModules[dat.Name]
+		s.lock.RUnlock()
+		if s.checkMiddleware(ctx, dat.Name) && (!inModule || module.checkMiddleware(ctx, dat.Name)) {
 			v.(*SlashCommand).Handler(ctx, pars)
 		}
 
 	case discordgo.InteractionMessageComponent:
 		ctx.component = true
 		dat := i.MessageComponentData()
-		parts := strings.SplitN(dat.CustomID, "|", 2)
+		id, exists, err := s.decodeCustomID(dat.CustomID)
+		if !s.checkCustomID(ctx, dat.CustomID, exists, err) {
+			return
+		}
 		switch dat.ComponentType {
 		case discordgo.ButtonComponent:
 			s.lock.RLock()
-			v, exists := s.buttonHandlers[parts[0]]
+			v, params, exists := s.buttonHandlers.find(id.handler)
 			s.lock.RUnlock()
 			if !exists {
+				s.unknownInteraction(ctx, UnknownInteractionButton, id.handler)
 				return
 			}
-			v(ctx, parts[1])
+			ctx.routeParams = params
+			v(ctx, id.params)
 
 		case discordgo.SelectMenuComponent, discordgo.ChannelSelectMenuComponent, discordgo.RoleSelectMenuComponent, discordgo.UserSelectMenuComponent, discordgo.MentionableSelectMenuComponent:
 			s.lock.RLock()
-			v, exists := s.selectHandlers[parts[0]]
+			v, params, exists := s.selectHandlers.find(id.handler)
 			s.lock.RUnlock()
 			if !exists {
+				s.unknownInteraction(ctx, UnknownInteractionSelect, id.handler)
 				return
 			}
+			ctx.routeParams = params
 
-			v(ctx, parts[1], dat.Values)
+			ctx.selectResolved, err = parseSelectResolved(raw, dat)
+			if err != nil {
+				Logger.Println("Error parsing select menu data", err)
+			}
+			v(ctx, id.params, dat.Values)
 		}
 
 	case discordgo.InteractionModalSubmit:
 		dat := i.ModalSubmitData()
 		ctx.component = true
 		ctx.modal = true
+		vals := make(ModalValues, len(dat.Components))
+		for _, comp := range dat.Components {
+			inp := comp.(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput)
+			vals[inp.CustomID] = inp.Value
+		}
+
+		if !strings.Contains(dat.CustomID, componentSeperator) { // One-off handler, custom ID is the ID of the interaction that opened it
+			handler, exists := s.takeModal(dat.CustomID)
+			if !exists {
+				ctx.respondError("This modal has expired.")
+				return
+			}
+			handler(ctx, vals)
+			return
+		}
+
+		id, exists, err := s.decodeCustomID(dat.CustomID)
+		if !s.checkCustomID(ctx, dat.CustomID, exists, err) {
+			return
+		}
 		s.lock.RLock()
-		handler, exists := s.modalHandlers[dat.CustomID]
+		named, params, exists := s.namedModals.find(id.handler)
 		s.lock.RUnlock()
 		if !exists {
+			s.unknownInteraction(ctx, UnknownInteractionModal, id.handler)
 			return
 		}
-		vals := make([]string, len(dat.Components))
-		for i, comp := range dat.Components {
-			vals[i] = comp.(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
-		}
-		handler(ctx, vals)
+		ctx.routeParams = params
+		named(ctx, id.params, vals)
+	}
+}
+
+// checkCustomID reports whether a decoded custom ID can be dispatched, responding with an error if not
+func (s *Sevcord) checkCustomID(ctx *InteractionCtx, customID string, exists bool, err error) bool {
+	if errors.Is(err, errInvalidSignature) {
+		Logger.Println("Rejected interaction with invalid signature from user", interactionUser(ctx.i).ID, customID)
+		ctx.respondError("Something went wrong, please try again.")
+		return false
+	}
+	if err != nil {
+		Logger.Println("Error loading component state", err)
+		ctx.respondError("Something went wrong, please try again.")
+		return false
+	}
+	if !exists {
+		ctx.respondError("This component has expired.")
+		return false
+	}
+	return true
+}
+
+// commandType gets the type of an application command interaction, which discordgo doesn't parse
+func commandType(raw json.RawMessage) discordgo.ApplicationCommandType {
+	var v struct {
+		Data struct {
+			Type discordgo.ApplicationCommandType `json:"type"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil || v.Data.Type == 0 {
+		return discordgo.ChatApplicationCommand
+	}
+	return v.Data.Type
+}
+
+// interactionUser gets the user who created an interaction, both in guilds and in DMs
+func interactionUser(i *discordgo.Interaction) *discordgo.User {
+	if i.Member != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+// ctxUser gets the user who created a ctx, both in guilds and in DMs, where Author has no member
+func ctxUser(ctx Ctx) *discordgo.User {
+	switch v := ctx.(type) {
+	case *InteractionCtx:
+		return interactionUser(v.i)
+
+	case *MessageCtx:
+		return v.m.Author
 	}
+	return ctx.Author().User
 }
 
 func optToAny(opt *discordgo.ApplicationCommandInteractionDataOption, i discordgo.ApplicationCommandInteractionData, s *discordgo.Session) any {
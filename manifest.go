@@ -0,0 +1,62 @@
+package sevcord
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CommandManifest is the set of commands sent to discord, each list is the body of a bulk overwrite request
+type CommandManifest struct {
+	Global []*discordgo.ApplicationCommand            `json:"global"`
+	Guilds map[string][]*discordgo.ApplicationCommand `json:"guilds,omitempty"` // Guild ID -> commands
+}
+
+// commandPayloads builds the payloads for all global and guild commands, sorted by name so that output is stable. The lock must be held
+func (s *Sevcord) commandPayloads() *CommandManifest {
+	out := &CommandManifest{
+		Global: sortedPayloads(s.commands, true),
+	}
+	for _, cmd := range s.contextMenus {
+		out.Global = append(out.Global, contextMenuDg(cmd))
+	}
+	sortPayloads(out.Global)
+	for guild, cmds := range s.guildCommands {
+		if out.Guilds == nil {
+			out.Guilds = make(map[string][]*discordgo.ApplicationCommand, len(s.guildCommands))
+		}
+		out.Guilds[guild] = sortedPayloads(cmds, false)
+	}
+	return out
+}
+
+func sortedPayloads(cmds map[string]SlashCommandObject, global bool) []*discordgo.ApplicationCommand {
+	out := make([]*discordgo.ApplicationCommand, 0, len(cmds))
+	for _, cmd := range cmds {
+		out = append(out, commandDg(cmd, global))
+	}
+	sortPayloads(out)
+	return out
+}
+
+func sortPayloads(cmds []*discordgo.ApplicationCommand) {
+	sort.Slice(cmds, func(i, j int) bool {
+		if cmds[i].Name != cmds[j].Name {
+			return cmds[i].Name < cmds[j].Name
+		}
+		return cmds[i].Type < cmds[j].Type
+	})
+}
+
+// ExportCommands writes the registered commands as a JSON CommandManifest, containing exactly what Listen sends to discord. Use the sevcord-commands tool to deploy a manifest separately from the bot
+func (s *Sevcord) ExportCommands(w io.Writer) error {
+	s.lock.RLock()
+	manifest := s.commandPayloads()
+	s.lock.RUnlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(manifest)
+}
@@ -0,0 +1,103 @@
+package sevcord
+
+import "time"
+
+// DefaultModalTTL is how long a modal opened with a one-off handler can be submitted for by default
+const DefaultModalTTL = 15 * time.Minute
+
+type pendingModal struct {
+	handler ModalHandler
+	modal   Modal // Only set for modals that can be reopened
+	expires time.Time
+}
+
+// SetModalTTL changes how long modals opened with a one-off handler can be submitted for. Modals with handlers added using AddModalHandler never expire
+func (s *Sevcord) SetModalTTL(ttl time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.modalTTL = ttl
+}
+
+// PendingModals returns the number of modals with one-off handlers that have been opened but not submitted or expired yet
+func (s *Sevcord) PendingModals() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	evictModals(s.modalHandlers)
+	return len(s.modalHandlers)
+}
+
+func (s *Sevcord) addModal(id string, handler ModalHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	evictModals(s.modalHandlers)
+	s.modalHandlers[id] = pendingModal{
+		handler: handler,
+		expires: time.Now().Add(s.modalTTL),
+	}
+	s.evictModalLater(s.modalHandlers, id, s.modalTTL)
+}
+
+// evictModalLater removes a modal once it expires, so that its handler isn't kept in memory if no more modals are opened. The lock must be held
+func (s *Sevcord) evictModalLater(modals map[string]pendingModal, id string, ttl time.Duration) {
+	time.AfterFunc(ttl, func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		if v, exists := modals[id]; exists && !time.Now().Before(v.expires) {
+			delete(modals, id)
+		}
+	})
+}
+
+// takeModal gets and removes the handler for a modal so that it can only be submitted once
+func (s *Sevcord) takeModal(id string) (ModalHandler, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	evictModals(s.modalHandlers)
+	v, exists := s.modalHandlers[id]
+	if !exists {
+		return nil, false
+	}
+	delete(s.modalHandlers, id)
+	return v.handler, true
+}
+
+// addReopenModal stores a modal so that it can be opened from a button, returning its key
+func (s *Sevcord) addReopenModal(m Modal) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	evictModals(s.reopenModals)
+	key := randomKey()
+	s.reopenModals[key] = pendingModal{
+		modal:   m,
+		expires: time.Now().Add(s.modalTTL),
+	}
+	s.evictModalLater(s.reopenModals, key, s.modalTTL)
+	return key
+}
+
+func (s *Sevcord) getReopenModal(key string) (Modal, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	v, exists := s.reopenModals[key]
+	if !exists || time.Now().After(v.expires) {
+		return Modal{}, false
+	}
+	return v.modal, true
+}
+
+// evictModals removes expired modals, the lock must be held
+func evictModals(modals map[string]pendingModal) {
+	now := time.Now()
+	for k, v := range modals {
+		if now.After(v.expires) {
+			delete(modals, k)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package sevcord
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Module bundles the commands, component handlers and middleware of a feature so that they can be loaded with Sevcord.Use
+type Module interface {
+	// Name is used as the namespace of the module's handler IDs, so it can't contain "/"
+	Name() string
+	// OnLoad registers everything the module needs. Nothing is registered if it returns an error
+	OnLoad(m *ModuleRegistry) error
+	// OnUnload is called before the module's commands and handlers are removed
+	OnUnload(m *ModuleRegistry) error
+}
+
+// ModuleRegistry registers the commands and handlers of a module. Handler IDs are namespaced with the module's name, so a button handler "buy" in the module "shop" is added as "shop/buy"
+type ModuleRegistry struct {
+	bot        *Sevcord
+	module     Module
+	commands   []SlashCommandObject
+	buttons    map[string]ButtonHandler
+	selects    map[string]SelectHandler
+	modals     map[string]NamedModalHandler
+	middleware []MiddlewareFunc
+}
+
+// Bot gets the bot that the module is loaded into
+func (m *ModuleRegistry) Bot() *Sevcord {
+	return m.bot
+}
+
+// ID namespaces a handler ID with the module's name, use this for the handler ID of components
+func (m *ModuleRegistry) ID(id string) string {
+	return m.module.Name() + routeSeperator + id
+}
+
+func (m *ModuleRegistry) RegisterSlashCommand(cmd SlashCommandObject) {
+	m.commands = append(m.commands, cmd)
+}
+
+func (m *ModuleRegistry) AddButtonHandler(id string, handler ButtonHandler) {
+	m.buttons[m.ID(id)] = handler
+}
+
+func (m *ModuleRegistry) AddSelectHandler(id string, handler SelectHandler) {
+	m.selects[m.ID(id)] = handler
+}
+
+func (m *ModuleRegistry) AddModalHandler(id string, handler NamedModalHandler) {
+	m.modals[m.ID(id)] = handler
+}
+
+// AddMiddleware adds middleware that is only run for the module's commands and handlers. For commands, it runs after the bot's middleware. Component and modal handlers don't run the bot's middleware, so only the module's middleware is run for them, with the handler ID passed as the command
+func (m *ModuleRegistry) AddMiddleware(mid MiddlewareFunc) {
+	m.middleware = append(m.middleware, mid)
+}
+
+func (m *ModuleRegistry) checkMiddleware(ctx Ctx, command string) bool {
+	for _, mid := range m.middleware {
+		if !mid(ctx, command) {
+			return false
+		}
+	}
+	return true
+}
+
+// conflicts lists everything the module registers that is invalid or already registered
+func (m *ModuleRegistry) conflicts() error {
+	s := m.bot
+	v := &ValidationError{}
+	names := make(map[string]struct{}, len(m.commands))
+	for _, cmd := range m.commands {
+		cmd.validate(v, "/"+cmd.name(), 0)
+		_, exists := s.commands[cmd.name()]
+		_, dup := names[cmd.name()]
+		if exists || dup {
+			v.add("commands["+cmd.name()+"]", "is already registered")
+		}
+		names[cmd.name()] = struct{}{}
+	}
+	for id := range m.buttons {
+		if other, exists := s.buttonHandlers.existing(id); exists {
+			v.add("buttons["+id+"]", "conflicts with %s", other)
+		}
+	}
+	for id := range m.selects {
+		if other, exists := s.selectHandlers.existing(id); exists {
+			v.add("selects["+id+"]", "conflicts with %s", other)
+		}
+	}
+	for id := range m.modals {
+		if other, exists := s.namedModals.existing(id); exists {
+			v.add("modals["+id+"]", "conflicts with %s", other)
+		}
+	}
+	if err := v.err(); err != nil {
+		return fmt.Errorf("sevcord: module %s: %w", m.module.Name(), err)
+	}
+	return nil
+}
+
+// Use loads a module, registering all of its commands and handlers. An error is returned without registering anything if any of them conflict with what is already registered, or if creating its commands on discord fails
+func (s *Sevcord) Use(module Module) error {
+	if module.Name() == "" || strings.Contains(module.Name(), routeSeperator) {
+		return fmt.Errorf("sevcord: invalid module name %q", module.Name())
+	}
+	s.lock.RLock()
+	_, exists := s.modules[module.Name()]
+	s.lock.RUnlock()
+	if exists {
+		return fmt.Errorf("sevcord: module %s is already loaded", module.Name())
+	}
+
+	reg := &ModuleRegistry{
+		bot:        s,
+		module:     module,
+		commands:   make([]SlashCommandObject, 0),
+		buttons:    make(map[string]ButtonHandler),
+		selects:    make(map[string]SelectHandler),
+		modals:     make(map[string]NamedModalHandler),
+		middleware: make([]MiddlewareFunc, 0),
+	}
+	if err := module.OnLoad(reg); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	if _, exists := s.modules[module.Name()]; exists { // Checked again since another call can load the module while OnLoad runs
+		s.lock.Unlock()
+		return fmt.Errorf("sevcord: module %s is already loaded", module.Name())
+	}
+	if err := reg.conflicts(); err != nil {
+		s.lock.Unlock()
+		return err
+	}
+	for _, cmd := range reg.commands {
+		s.commands[cmd.name()] = cmd
+		s.commandModules[cmd.name()] = reg
+	}
+	for id, handler := range reg.buttons {
+		id, handler := id, handler
+		s.buttonHandlers.add(id, func(ctx Ctx, params string) {
+			if reg.checkMiddleware(ctx, id) {
+				handler(ctx, params)
+			}
+		})
+	}
+	for id, handler := range reg.selects {
+		id, handler := id, handler
+		s.selectHandlers.add(id, func(ctx Ctx, params string, selected []string) {
+			if reg.checkMiddleware(ctx, id) {
+				handler(ctx, params, selected)
+			}
+		})
+	}
+	for id, handler := range reg.modals {
+		id, handler := id, handler
+		s.namedModals.add(id, func(ctx Ctx, params string, values ModalValues) {
+			if reg.checkMiddleware(ctx, id) {
+				handler(ctx, params, values)
+			}
+		})
+	}
+	s.modules[module.Name()] = reg
+	s.lock.Unlock()
+
+	// Create commands on discord if connected
+	for i, cmd := range reg.commands {
+		if err := s.syncCommand("", cmd); err != nil {
+			// Roll back so that loading the module can be retried
+			s.lock.Lock()
+			s.removeModule(reg)
+			s.lock.Unlock()
+			for _, synced := range reg.commands[:i] {
+				if err := s.syncDelete("", synced.name()); err != nil {
+					Logger.Println("Error deleting command of module", module.Name(), err)
+				}
+			}
+			if err := module.OnUnload(reg); err != nil {
+				Logger.Println("Error unloading module", module.Name(), err)
+			}
+			return fmt.Errorf("sevcord: module %s: %w", module.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Unuse unloads a module, removing all of its commands and handlers
+func (s *Sevcord) Unuse(name string) error {
+	s.lock.RLock()
+	reg, exists := s.modules[name]
+	s.lock.RUnlock()
+	if !exists {
+		return fmt.Errorf("sevcord: module %s isn't loaded", name)
+	}
+	if err := reg.module.OnUnload(reg); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	removed := s.removeModule(reg)
+	s.lock.Unlock()
+
+	// Delete commands on discord if connected
+	for _, name := range removed {
+		if err := s.syncDelete("", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeModule removes the commands and handlers of a module, returning the names of the removed commands. The lock must be held
+func (s *Sevcord) removeModule(reg *ModuleRegistry) []string {
+	removed := make([]string, 0, len(reg.commands))
+	for _, cmd := range reg.commands {
+		if s.commandModules[cmd.name()] != reg { // Replaced or unregistered since the module was loaded
+			continue
+		}
+		delete(s.commands, cmd.name())
+		delete(s.commandModules, cmd.name())
+		removed = append(removed, cmd.name())
+	}
+	for id := range reg.buttons {
+		s.buttonHandlers.remove(id)
+	}
+	for id := range reg.selects {
+		s.selectHandlers.remove(id)
+	}
+	for id := range reg.modals {
+		s.namedModals.remove(id)
+	}
+	delete(s.modules, reg.module.Name())
+	return removed
+}
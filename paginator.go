@@ -0,0 +1,204 @@
+package sevcord
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pageHandler is the ID of the button and select handler that navigates paginators
+const pageHandler = "sevcord.page"
+
+// DefaultPaginatorTimeout is how long paginators can be used for by default
+const DefaultPaginatorTimeout = 5 * time.Minute
+
+// maxSelectOptions is the most options discord allows in a select menu
+const maxSelectOptions = 25
+
+// Paginator displays a message with many pages, with buttons to navigate between them
+type Paginator struct {
+	count      int
+	page       func(page int) MessageSend
+	jump       bool
+	onlyAuthor bool
+	timeout    time.Duration
+}
+
+// NewPaginator creates a paginator with the pages provided
+func NewPaginator(pages ...MessageSend) *Paginator {
+	return NewDynamicPaginator(len(pages), func(page int) MessageSend {
+		return pages[page]
+	})
+}
+
+// NewDynamicPaginator creates a paginator with count pages, which are created by calling page with the index of the page (starting at 0) when they are viewed
+func NewDynamicPaginator(count int, page func(page int) MessageSend) *Paginator {
+	return &Paginator{
+		count:   count,
+		page:    page,
+		timeout: DefaultPaginatorTimeout,
+	}
+}
+
+// JumpSelect adds a select menu to jump to any page
+func (p *Paginator) JumpSelect(enabled bool) *Paginator {
+	p.jump = enabled
+	return p
+}
+
+// OnlyAuthor only lets the user who the paginator was sent to navigate it
+func (p *Paginator) OnlyAuthor(enabled bool) *Paginator {
+	p.onlyAuthor = enabled
+	return p
+}
+
+// Timeout changes how long the paginator can be used for, after which its buttons are disabled. Note that interactions can only be edited for 15 minutes
+func (p *Paginator) Timeout(timeout time.Duration) *Paginator {
+	p.timeout = timeout
+	return p
+}
+
+type paginatorState struct {
+	lock   *sync.Mutex
+	p      *Paginator
+	page   int
+	author string
+}
+
+// Send responds with the first page of the paginator
+func (p *Paginator) Send(ctx Ctx) error {
+	if p.count < 1 {
+		return errors.New("sevcord: paginator has no pages")
+	}
+	s, editable, err := ctxEditable(ctx)
+	if err != nil {
+		return err
+	}
+	key := randomKey()
+	state := &paginatorState{
+		lock: &sync.Mutex{},
+		p:    p,
+	}
+	if p.onlyAuthor {
+		state.author = ctxUser(ctx).ID
+	}
+
+	s.lock.Lock()
+	s.paginators[key] = state
+	s.lock.Unlock()
+
+	edit, err := editable.respondEditable(p.render(key, 0, false))
+	if err != nil {
+		s.lock.Lock()
+		delete(s.paginators, key)
+		s.lock.Unlock()
+		return err
+	}
+
+	time.AfterFunc(p.timeout, func() {
+		s.lock.Lock()
+		delete(s.paginators, key)
+		s.lock.Unlock()
+
+		state.lock.Lock()
+		page := state.page
+		state.lock.Unlock()
+		msg, err := s.messageDg(p.render(key, page, true))
+		if err == nil {
+			err = edit(msg.Components)
+		}
+		if err != nil {
+			Logger.Println("Error disabling paginator", err)
+		}
+	})
+	return nil
+}
+
+// render creates the message for a page, with navigation components added
+func (p *Paginator) render(key string, page int, disabled bool) MessageSend {
+	msg := p.page(page)
+	last := p.count - 1
+	// Params are key|page|slot, the slot makes sure that no two buttons have the same custom ID
+	nav := func(label string, target int, slot string) *Button {
+		return NewButton(label, ButtonStyleSecondary, pageHandler, strings.Join([]string{key, strconv.Itoa(target), slot}, componentSeperator)).
+			SetDisabled(disabled || target == page || target < 0 || target > last)
+	}
+	msg = msg.AddComponentRow(
+		nav("⏮", 0, "f"),
+		nav("◀", page-1, "p"),
+		nav(strconv.Itoa(page+1)+"/"+strconv.Itoa(p.count), page, "c"),
+		nav("▶", page+1, "n"),
+		nav("⏭", last, "l"),
+	)
+
+	if p.jump && p.count > 1 {
+		// Show pages around the current page if there are too many to fit
+		start := page - maxSelectOptions/2
+		if start > p.count-maxSelectOptions {
+			start = p.count - maxSelectOptions
+		}
+		if start < 0 {
+			start = 0
+		}
+		sel := NewSelect("Jump to page", pageHandler, key).SetDisabled(disabled)
+		for i := start; i < p.count && i < start+maxSelectOptions; i++ {
+			sel.Option(NewSelectOption("Page "+strconv.Itoa(i+1), "", strconv.Itoa(i)).SetDefault(i == page))
+		}
+		msg = msg.AddComponentRow(sel)
+	}
+	return msg
+}
+
+func (s *Sevcord) navigatePaginator(ctx Ctx, params string, selected []string) {
+	ictx := ctx.(*InteractionCtx)
+	parts := strings.Split(params, componentSeperator)
+	if len(selected) > 0 {
+		parts = append(parts[:1], selected[0])
+	}
+	if len(parts) < 2 {
+		ictx.respondError("This component is invalid.")
+		return
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil {
+		ictx.respondError("This component is invalid.")
+		return
+	}
+
+	s.lock.RLock()
+	state, exists := s.paginators[parts[0]]
+	s.lock.RUnlock()
+	if !exists {
+		ictx.respondError("This paginator has expired.")
+		return
+	}
+	if state.author != "" && state.author != interactionUser(ictx.i).ID {
+		ictx.respondError("You can't use this paginator.")
+		return
+	}
+	if page < 0 || page >= state.p.count {
+		ictx.respondError("That page doesn't exist.")
+		return
+	}
+
+	state.lock.Lock()
+	state.page = page
+	state.lock.Unlock()
+	if err := ictx.Respond(state.p.render(parts[0], page, false)); err != nil {
+		Logger.Println("Error updating paginator", err)
+	}
+}
+
+// ctxSevcord gets the bot that created a context
+func ctxSevcord(ctx Ctx) *Sevcord {
+	switch v := ctx.(type) {
+	case *InteractionCtx:
+		return v.s
+
+	case *MessageCtx:
+		return v.s
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package sevcord
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// permissionNames are the names discord shows for permissions, in the order discord shows them
+var permissionNames = []struct {
+	bit  int64
+	name string
+}{
+	{discordgo.PermissionAdministrator, "Administrator"},
+	{discordgo.PermissionViewAuditLogs, "View Audit Log"},
+	{discordgo.PermissionManageServer, "Manage Server"},
+	{discordgo.PermissionManageRoles, "Manage Roles"},
+	{discordgo.PermissionManageChannels, "Manage Channels"},
+	{discordgo.PermissionKickMembers, "Kick Members"},
+	{discordgo.PermissionBanMembers, "Ban Members"},
+	{discordgo.PermissionModerateMembers, "Timeout Members"},
+	{discordgo.PermissionCreateInstantInvite, "Create Invite"},
+	{discordgo.PermissionChangeNickname, "Change Nickname"},
+	{discordgo.PermissionManageNicknames, "Manage Nicknames"},
+	{discordgo.PermissionManageEmojis, "Manage Emojis and Stickers"},
+	{discordgo.PermissionManageWebhooks, "Manage Webhooks"},
+	{discordgo.PermissionManageEvents, "Manage Events"},
+	{discordgo.PermissionViewGuildInsights, "View Server Insights"},
+	{discordgo.PermissionViewChannel, "View Channels"},
+	{discordgo.PermissionSendMessages, "Send Messages"},
+	{discordgo.PermissionSendMessagesInThreads, "Send Messages in Threads"},
+	{discordgo.PermissionCreatePublicThreads, "Create Public Threads"},
+	{discordgo.PermissionCreatePrivateThreads, "Create Private Threads"},
+	{discordgo.PermissionSendTTSMessages, "Send Text-to-Speech Messages"},
+	{discordgo.PermissionManageMessages, "Manage Messages"},
+	{discordgo.PermissionManageThreads, "Manage Threads"},
+	{discordgo.PermissionEmbedLinks, "Embed Links"},
+	{discordgo.PermissionAttachFiles, "Attach Files"},
+	{discordgo.PermissionReadMessageHistory, "Read Message History"},
+	{discordgo.PermissionMentionEveryone, "Mention @everyone"},
+	{discordgo.PermissionUseExternalEmojis, "Use External Emoji"},
+	{discordgo.PermissionUseExternalStickers, "Use External Stickers"},
+	{discordgo.PermissionAddReactions, "Add Reactions"},
+	{discordgo.PermissionUseSlashCommands, "Use Application Commands"},
+	{discordgo.PermissionVoiceConnect, "Connect"},
+	{discordgo.PermissionVoiceSpeak, "Speak"},
+	{discordgo.PermissionVoiceStreamVideo, "Video"},
+	{discordgo.PermissionUseActivities, "Use Activities"},
+	{discordgo.PermissionVoiceUseVAD, "Use Voice Activity"},
+	{discordgo.PermissionVoicePrioritySpeaker, "Priority Speaker"},
+	{discordgo.PermissionVoiceMuteMembers, "Mute Members"},
+	{discordgo.PermissionVoiceDeafenMembers, "Deafen Members"},
+	{discordgo.PermissionVoiceMoveMembers, "Move Members"},
+	{discordgo.PermissionVoiceRequestToSpeak, "Request to Speak"},
+}
+
+// PermissionNames gets the names of the permissions in a discordgo permissions bit mask. A mask of 0 means only administrators can use a command, so "Administrator" is returned
+func PermissionNames(p int64) []string {
+	if p == 0 {
+		return []string{"Administrator"}
+	}
+	out := make([]string, 0)
+	for _, v := range permissionNames {
+		if p&v.bit != 0 {
+			out = append(out, v.name)
+		}
+	}
+	return out
+}
+
+// hasPermissions checks whether a member with perms can use a command that requires the permissions, like discord does
+func hasPermissions(perms int64, required *int64) bool {
+	if required == nil || perms&discordgo.PermissionAdministrator != 0 {
+		return true
+	}
+	if *required == 0 { // Only administrators
+		return false
+	}
+	return perms&*required == *required
+}
+
+// guildRolesTTL is how long the roles of a guild are cached for checking the permissions of text commands, so role changes can take this long to apply to them
+const guildRolesTTL = 5 * time.Minute
+
+// guildRoles are the roles of a guild, used to get the permissions of message authors
+type guildRoles struct {
+	owner   string
+	roles   map[string]int64 // Role ID -> permissions
+	fetched time.Time
+}
+
+// getGuildRoles gets the roles of a guild from the state if the bot has the guilds intent, otherwise fetching them at most once every guildRolesTTL
+func (s *Sevcord) getGuildRoles(d *discordgo.Session, guild string) (*guildRoles, error) {
+	s.lock.RLock()
+	v, exists := s.guildRoles[guild]
+	s.lock.RUnlock()
+	if exists && time.Since(v.fetched) < guildRolesTTL {
+		return v, nil
+	}
+
+	g, err := d.State.Guild(guild)
+	if err != nil {
+		g, err = d.Guild(guild)
+		if err != nil {
+			return nil, err
+		}
+	}
+	v = &guildRoles{
+		owner:   g.OwnerID,
+		roles:   make(map[string]int64, len(g.Roles)),
+		fetched: time.Now(),
+	}
+	for _, role := range g.Roles {
+		v.roles[role.ID] = role.Permissions
+	}
+
+	s.lock.Lock()
+	s.guildRoles[guild] = v
+	s.lock.Unlock()
+	return v, nil
+}
+
+// ctxPermissions gets the permissions of the author of a context, returning false outside of guilds. For interactions, these are the author's permissions in the channel. For messages, they are calculated from the author's roles without channel overwrites, like discord does for command permissions
+func ctxPermissions(ctx Ctx) (int64, bool, error) {
+	switch v := ctx.(type) {
+	case *InteractionCtx:
+		if v.i.Member == nil {
+			return 0, false, nil
+		}
+		return v.i.Member.Permissions, true, nil
+
+	case *MessageCtx:
+		if v.m.GuildID == "" {
+			return 0, false, nil
+		}
+		if v.m.Member == nil {
+			return 0, true, errors.New("sevcord: message has no member")
+		}
+		g, err := v.s.getGuildRoles(v.d, v.m.GuildID)
+		if err != nil {
+			return 0, true, err
+		}
+		if v.m.Author.ID == g.owner {
+			return discordgo.PermissionAll, true, nil
+		}
+		perms := g.roles[v.m.GuildID] // @everyone has the guild's ID
+		for _, role := range v.m.Member.Roles {
+			perms |= g.roles[role]
+		}
+		if perms&discordgo.PermissionAdministrator != 0 {
+			perms = discordgo.PermissionAll
+		}
+		return perms, true, nil
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,198 @@
+package sevcord
+
+import (
+	"sort"
+	"strings"
+)
+
+// routeSeperator separates the segments of a handler ID. Handler IDs can be patterns, where a segment starting with routeParam captures that segment and routeWildcard as the last segment captures the rest of the ID, for example "shop/:item/buy" or "shop/*"
+const (
+	routeSeperator = "/"
+	routeParam     = ":"
+	routeWildcard  = "*"
+)
+
+type route[H any] struct {
+	pattern  string
+	segments []string
+	handler  H
+}
+
+// router finds the handler for a handler ID, by exact match or by pattern
+type router[H any] struct {
+	exact     map[string]H
+	patterns  []route[H] // Sorted so that more specific patterns are matched first
+	conflicts []string   // IDs registered more than once and patterns that overlap with a pattern of the same rank
+}
+
+func newRouter[H any]() *router[H] {
+	return &router[H]{
+		exact:     make(map[string]H),
+		patterns:  make([]route[H], 0),
+		conflicts: make([]string, 0),
+	}
+}
+
+func isPattern(id string) bool {
+	for _, seg := range strings.Split(id, routeSeperator) {
+		if seg == routeWildcard || strings.HasPrefix(seg, routeParam) {
+			return true
+		}
+	}
+	return false
+}
+
+// existing gets the registered ID or pattern that an ID would conflict with. Patterns conflict if they have the same rank and some ID matches both, since neither would be preferred
+func (r *router[H]) existing(id string) (string, bool) {
+	if !isPattern(id) {
+		_, exists := r.exact[id]
+		return id, exists
+	}
+	segments := strings.Split(id, routeSeperator)
+	for _, v := range r.patterns {
+		if compareRoutes(v.segments, segments) == 0 && routesOverlap(v.segments, segments) {
+			return v.pattern, true
+		}
+	}
+	return "", false
+}
+
+// add registers a handler, recording a conflict instead if the ID or an overlapping pattern of the same rank is already registered
+func (r *router[H]) add(id string, handler H) {
+	if other, exists := r.existing(id); exists {
+		if other != id {
+			id += " (conflicts with " + other + ")"
+		}
+		r.conflicts = append(r.conflicts, id)
+		return
+	}
+	if !isPattern(id) {
+		r.exact[id] = handler
+		return
+	}
+
+	r.patterns = append(r.patterns, route[H]{
+		pattern:  id,
+		segments: strings.Split(id, routeSeperator),
+		handler:  handler,
+	})
+	sort.SliceStable(r.patterns, func(i, j int) bool {
+		return compareRoutes(r.patterns[i].segments, r.patterns[j].segments) > 0
+	})
+}
+
+// remove unregisters the handler for an ID or pattern
+func (r *router[H]) remove(id string) {
+	delete(r.exact, id)
+	for i, v := range r.patterns {
+		if v.pattern == id {
+			r.patterns = append(r.patterns[:i], r.patterns[i+1:]...)
+			return
+		}
+	}
+}
+
+// segmentRank ranks the segment of a pattern at index i, literal segments rank above params, which rank above wildcards
+func segmentRank(segments []string, i int) int {
+	switch seg := segments[i]; {
+	case seg == routeWildcard && i == len(segments)-1:
+		return 0
+
+	case strings.HasPrefix(seg, routeParam):
+		return 1
+	}
+	return 2
+}
+
+// compareRoutes compares patterns segment by segment from the left, returning a positive number if a is more specific than b, a negative number if b is more specific and 0 if they have the same rank
+func compareRoutes(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if diff := segmentRank(a, i) - segmentRank(b, i); diff != 0 {
+			return diff
+		}
+	}
+	return 0
+}
+
+// routesOverlap checks whether some ID matches both patterns
+func routesOverlap(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		rankA, rankB := segmentRank(a, i), segmentRank(b, i)
+		if rankA == 0 || rankB == 0 { // A wildcard matches the rest of the other pattern
+			return true
+		}
+		if rankA == 2 && rankB == 2 && a[i] != b[i] {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+// find gets the handler for an ID, along with the segments captured by its pattern
+func (r *router[H]) find(id string) (H, map[string]string, bool) {
+	if v, exists := r.exact[id]; exists {
+		return v, nil, true
+	}
+
+	segments := strings.Split(id, routeSeperator)
+	for _, v := range r.patterns {
+		if captures, ok := matchRoute(v.segments, segments); ok {
+			return v.handler, captures, true
+		}
+	}
+	var empty H
+	return empty, nil, false
+}
+
+func matchRoute(pattern, segments []string) (map[string]string, bool) {
+	captures := make(map[string]string)
+	for i, seg := range pattern {
+		if seg == routeWildcard && i == len(pattern)-1 {
+			if i >= len(segments) {
+				return nil, false
+			}
+			captures[routeWildcard] = strings.Join(segments[i:], routeSeperator)
+			return captures, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		switch {
+		case strings.HasPrefix(seg, routeParam):
+			if segments[i] == "" {
+				return nil, false
+			}
+			captures[strings.TrimPrefix(seg, routeParam)] = segments[i]
+
+		case seg != segments[i]:
+			return nil, false
+		}
+	}
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+	return captures, true
+}
+
+// RouteParams gets the segments of the handler ID captured by the pattern the handler was added with. For example, a handler added with "shop/:item/buy" that handles "shop/apple/buy" gets {"item": "apple"}. Wildcards are stored under "*"
+func (i *InteractionCtx) RouteParams() map[string]string {
+	return i.routeParams
+}
+
+// routeConflicts lists every conflicting handler registration
+func (s *Sevcord) routeConflicts() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	v := &ValidationError{}
+	for _, c := range s.buttonHandlers.conflicts {
+		v.add("buttons["+c+"]", "is registered more than once or overlaps another pattern")
+	}
+	for _, c := range s.selectHandlers.conflicts {
+		v.add("selects["+c+"]", "is registered more than once or overlaps another pattern")
+	}
+	for _, c := range s.namedModals.conflicts {
+		v.add("modals["+c+"]", "is registered more than once or overlaps another pattern")
+	}
+	return v.err()
+}
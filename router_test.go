@@ -0,0 +1,75 @@
+package sevcord
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRouterFind(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		id       string
+		want     string
+	}{
+		{"exact before pattern", []string{"shop/:item/buy", "shop/apple/buy"}, "shop/apple/buy", "shop/apple/buy"},
+		{"earlier literal wins", []string{"shop/:a/buy", "shop/apple/:b"}, "shop/apple/buy", "shop/apple/:b"},
+		{"earlier literal wins, reversed", []string{"shop/apple/:b", "shop/:a/buy"}, "shop/apple/buy", "shop/apple/:b"},
+		{"literal prefix beats more literals", []string{":g/settings/:k", "shop/*"}, "shop/settings/x", "shop/*"},
+		{"param beats wildcard", []string{"shop/*", "shop/:item"}, "shop/apple", "shop/:item"},
+		{"wildcard matches the rest", []string{"shop/*", "shop/:item"}, "shop/apple/buy", "shop/*"},
+		{"no match", []string{"shop/:item"}, "shop", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newRouter[string]()
+			for _, p := range test.patterns {
+				r.add(p, p)
+			}
+			if len(r.conflicts) > 0 {
+				t.Fatalf("unexpected conflicts %v", r.conflicts)
+			}
+			got, _, _ := r.find(test.id)
+			if got != test.want {
+				t.Errorf("%s matched %q, want %q", test.id, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRouterConflicts(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		conflict bool
+	}{
+		{"vote", "vote", true},
+		{"shop/:a/buy", "shop/:b/buy", true},
+		{"shop/*", "shop/*", true},
+		{":a/*", ":b/*", true},
+		{"shop/:a/*", "shop/:b/*", true},
+		{"shop/:a/buy", "shop/:b/sell", false},
+		{"shop/:a/buy", "shop/apple/:b", false},
+		{"shop/:a", "shop/:a/:b", false},
+		{"shop/:a", "cart/:a", false},
+	}
+	for _, test := range tests {
+		r := newRouter[string]()
+		r.add(test.a, test.a)
+		r.add(test.b, test.b)
+		if got := len(r.conflicts) > 0; got != test.conflict {
+			t.Errorf("%s and %s: got conflicts %v, want conflict %v", test.a, test.b, r.conflicts, test.conflict)
+		}
+	}
+}
+
+func TestRouterCaptures(t *testing.T) {
+	r := newRouter[string]()
+	r.add("shop/:item/*", "")
+	_, captures, ok := r.find("shop/apple/buy/5")
+	if !ok {
+		t.Fatal("no match")
+	}
+	if captures["item"] != "apple" || captures[routeWildcard] != strings.Join([]string{"buy", "5"}, routeSeperator) {
+		t.Errorf("unexpected captures %v", captures)
+	}
+}
@@ -0,0 +1,132 @@
+package sevcord
+
+import (
+	"encoding/json"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// SelectResolved contains the users, members, roles and channels selected in an auto-populated select menu, which discord sends along with their IDs
+type SelectResolved struct {
+	Kind SelectKind
+
+	values   []string
+	users    map[string]*discordgo.User
+	members  map[string]*discordgo.Member
+	roles    map[string]*discordgo.Role
+	channels map[string]*discordgo.Channel
+}
+
+// SelectedMentionable is a user or role selected in a mentionable select menu
+type SelectedMentionable struct {
+	ID     string
+	IsRole bool
+
+	User   *discordgo.User   // Only set if IsRole is false
+	Member *discordgo.Member // Only set if IsRole is false and the select menu is in a guild
+	Role   *discordgo.Role   // Only set if IsRole is true
+}
+
+// Users gets the selected users, in the order they were selected. Works for user and mentionable select menus
+func (s *SelectResolved) Users() []*discordgo.User {
+	out := make([]*discordgo.User, 0, len(s.values))
+	for _, id := range s.values {
+		if v, exists := s.users[id]; exists {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Member gets the member of a selected user, returning nil outside of guilds
+func (s *SelectResolved) Member(id string) *discordgo.Member {
+	v, exists := s.members[id]
+	if !exists {
+		return nil
+	}
+	v.User = s.users[id]
+	return v
+}
+
+// Roles gets the selected roles, in the order they were selected. Works for role and mentionable select menus
+func (s *SelectResolved) Roles() []*discordgo.Role {
+	out := make([]*discordgo.Role, 0, len(s.values))
+	for _, id := range s.values {
+		if v, exists := s.roles[id]; exists {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Channels gets the selected channels, in the order they were selected. Note that discord only sends some of the fields of each channel
+func (s *SelectResolved) Channels() []*discordgo.Channel {
+	out := make([]*discordgo.Channel, 0, len(s.values))
+	for _, id := range s.values {
+		if v, exists := s.channels[id]; exists {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Mentionables gets the selected users and roles of a mentionable select menu, in the order they were selected
+func (s *SelectResolved) Mentionables() []SelectedMentionable {
+	out := make([]SelectedMentionable, 0, len(s.values))
+	for _, id := range s.values {
+		if v, exists := s.roles[id]; exists {
+			out = append(out, SelectedMentionable{ID: id, IsRole: true, Role: v})
+		} else if v, exists := s.users[id]; exists {
+			out = append(out, SelectedMentionable{ID: id, User: v, Member: s.Member(id)})
+		}
+	}
+	return out
+}
+
+// SelectResolved gets the data discord sent about the values selected in an auto-populated select menu, returning nil if this interaction isn't from one
+func (i *InteractionCtx) SelectResolved() *SelectResolved {
+	return i.selectResolved
+}
+
+// parseSelectResolved parses the resolved data of a select menu interaction, which discordgo doesn't support, from the raw interaction
+func parseSelectResolved(raw json.RawMessage, dat discordgo.MessageComponentInteractionData) (*SelectResolved, error) {
+	var kind SelectKind
+	switch dat.ComponentType {
+	case discordgo.UserSelectMenuComponent:
+		kind = SelectKindUser
+
+	case discordgo.RoleSelectMenuComponent:
+		kind = SelectKindRole
+
+	case discordgo.MentionableSelectMenuComponent:
+		kind = SelectKindMentionable
+
+	case discordgo.ChannelSelectMenuComponent:
+		kind = SelectKindChannel
+
+	default:
+		return nil, nil
+	}
+
+	var v struct {
+		Data struct {
+			Resolved struct {
+				Users    map[string]*discordgo.User    `json:"users"`
+				Members  map[string]*discordgo.Member  `json:"members"`
+				Roles    map[string]*discordgo.Role    `json:"roles"`
+				Channels map[string]*discordgo.Channel `json:"channels"`
+			} `json:"resolved"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return &SelectResolved{
+		Kind:     kind,
+		values:   dat.Values,
+		users:    v.Data.Resolved.Users,
+		members:  v.Data.Resolved.Members,
+		roles:    v.Data.Resolved.Roles,
+		channels: v.Data.Resolved.Channels,
+	}, nil
+}
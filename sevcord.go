@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -24,17 +25,34 @@ type Sevcord struct {
 	dg             *discordgo.Session // Note: only use this to create cmds, give one provided with handlers for user
 	middleware     []MiddlewareFunc
 	messageHandler MessageHandler
+	textCommands   *TextCommandOptions
 	commands       map[string]SlashCommandObject
-	buttonHandlers map[string]ButtonHandler
-	selectHandlers map[string]SelectHandler
-	modalHandlers  map[string]ModalHandler
+	guildCommands  map[string]map[string]SlashCommandObject
+	contextMenus   map[contextMenuKey]*ContextMenuCommand
+	commandIDs     map[string]map[string]string // Guild ("" for global) -> name -> ID of commands created on discord
+	appID          string                       // Set once connected
+	buttonHandlers *router[ButtonHandler]
+	selectHandlers *router[SelectHandler]
+	modalHandlers  map[string]pendingModal
+	modalTTL       time.Duration
+	reopenModals   map[string]pendingModal
+	namedModals    *router[NamedModalHandler]
+	stateStore     StateStore
+	stateTTL       time.Duration
+	signingKey     []byte
+	collectors     map[string]*collector
+	expiryTimers   map[string]*time.Timer // Message key -> timer that disables its components, see messageKeys
+	paginators     map[string]*paginatorState
+	wizards        map[string]*WizardSession
+	unknownHandler UnknownInteractionHandler
+	modules        map[string]*ModuleRegistry
+	commandModules map[string]*ModuleRegistry // Module that registered each command
+	guildRoles     map[string]*guildRoles     // Guild ID -> roles, for text command permissions
 }
 
-func (s *Sevcord) RegisterSlashCommand(cmd SlashCommandObject) {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-
-	s.commands[cmd.name()] = cmd
+// RegisterSlashCommand registers a global command. An error is returned if the command is invalid (see SlashCommand.Validate). If the bot is connected, the command is created immediately
+func (s *Sevcord) RegisterSlashCommand(cmd SlashCommandObject) error {
+	return s.registerCommand("", cmd)
 }
 
 func (s *Sevcord) SetMessageHandler(handler MessageHandler) {
@@ -47,18 +65,42 @@ func New(token string) (*Sevcord, error) {
 		return nil, err
 	}
 	dg.Identify.Intents = discordgo.IntentsNone
-	return &Sevcord{
+	s := &Sevcord{
 		lock:           &sync.RWMutex{},
 		dg:             dg,
 		middleware:     make([]MiddlewareFunc, 0),
 		commands:       make(map[string]SlashCommandObject),
-		buttonHandlers: make(map[string]ButtonHandler),
-		selectHandlers: make(map[string]SelectHandler),
-		modalHandlers:  make(map[string]ModalHandler),
-	}, nil
+		guildCommands:  make(map[string]map[string]SlashCommandObject),
+		contextMenus:   make(map[contextMenuKey]*ContextMenuCommand),
+		commandIDs:     make(map[string]map[string]string),
+		buttonHandlers: newRouter[ButtonHandler](),
+		selectHandlers: newRouter[SelectHandler](),
+		modalHandlers:  make(map[string]pendingModal),
+		modalTTL:       DefaultModalTTL,
+		reopenModals:   make(map[string]pendingModal),
+		namedModals:    newRouter[NamedModalHandler](),
+		stateStore:     NewMemoryStateStore(),
+		stateTTL:       DefaultStateTTL,
+		collectors:     make(map[string]*collector),
+		expiryTimers:   make(map[string]*time.Timer),
+		paginators:     make(map[string]*paginatorState),
+		wizards:        make(map[string]*WizardSession),
+		unknownHandler: defaultUnknownInteractionHandler,
+		modules:        make(map[string]*ModuleRegistry),
+		commandModules: make(map[string]*ModuleRegistry),
+		guildRoles:     make(map[string]*guildRoles),
+	}
+	s.buttonHandlers.add(reopenModalHandler, s.reopenModal)
+	s.buttonHandlers.add(collectHandler, func(ctx Ctx, params string) { s.collect(ctx, params, nil) })
+	s.selectHandlers.add(collectHandler, s.collect)
+	s.buttonHandlers.add(pageHandler, func(ctx Ctx, params string) { s.navigatePaginator(ctx, params, nil) })
+	s.selectHandlers.add(pageHandler, s.navigatePaginator)
+	s.buttonHandlers.add(wizardHandler, func(ctx Ctx, params string) { s.wizardInteraction(ctx, params, nil) })
+	s.selectHandlers.add(wizardHandler, s.wizardInteraction)
+	return s, nil
 }
 
-// AddMiddleware adds middleware, a function that is run before every command handler is called. Middleware is run in the order it is added. Note that middleware is not run for message handlers
+// AddMiddleware adds middleware, a function that is run before every command handler is called, including for text commands. Middleware is run in the order it is added. Note that middleware is not run for message handlers
 func (s *Sevcord) AddMiddleware(m MiddlewareFunc) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -66,18 +108,28 @@ func (s *Sevcord) AddMiddleware(m MiddlewareFunc) {
 	s.middleware = append(s.middleware, m)
 }
 
+// AddButtonHandler adds a handler for buttons with the handler ID. The ID can be a pattern like "shop/:item/buy" or "shop/*", use InteractionCtx.RouteParams to get the captured segments. Exact IDs are matched first, then patterns are compared segment by segment from the left, preferring literal segments over params and params over wildcards. Registering an ID twice, or two patterns that can match the same ID with the same rank, is reported as an error by Listen
 func (s *Sevcord) AddButtonHandler(id string, handler ButtonHandler) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	s.buttonHandlers[id] = handler
+	s.buttonHandlers.add(id, handler)
 }
 
+// AddSelectHandler adds a handler for select menus with the handler ID, which can be a pattern like in AddButtonHandler
 func (s *Sevcord) AddSelectHandler(id string, handler SelectHandler) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	s.selectHandlers[id] = handler
+	s.selectHandlers.add(id, handler)
+}
+
+// AddModalHandler adds a handler for modals created with NewNamedModal with the handler ID, which can be a pattern like in AddButtonHandler
+func (s *Sevcord) AddModalHandler(id string, handler NamedModalHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.namedModals.add(id, handler)
 }
 
 // Dg gets the global discordgo session. NOTE: Only use this to add handlers/intents, use the one provided with Ctx for anything else
@@ -85,35 +137,30 @@ func (s *Sevcord) Dg() *discordgo.Session {
 	return s.dg
 }
 
-func (s *Sevcord) Listen() {
-	s.lock.RLock()
-	// Build commands
-	cmds := make([]*discordgo.ApplicationCommand, 0, len(s.commands))
-	for _, cmd := range s.commands {
-		v := cmd.dg()
-		dmPermission := false
-		cmds = append(cmds, &discordgo.ApplicationCommand{
-			Name:        v.Name,
-			Description: v.Description,
-			Options:     v.Options,
-			Type:        discordgo.ChatApplicationCommand,
-
-			DefaultMemberPermissions: cmd.permissions(),
-			DMPermission:             &dmPermission,
-		})
+// Listen connects to discord and blocks until the program is interrupted. An error is returned before connecting if any handlers conflict
+func (s *Sevcord) Listen() error {
+	if err := s.routeConflicts(); err != nil {
+		return err
 	}
-	s.lock.RUnlock()
 
 	// Handlers
-	s.dg.AddHandler(s.interactionHandler)
-	s.dg.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) {
-		_, err := s.ApplicationCommandBulkOverwrite(s.State.User.ID, "", cmds)
+	s.dg.AddHandler(func(dg *discordgo.Session, e *discordgo.Event) { // Raw event so that data discordgo doesn't parse is available
+		if i, ok := e.Struct.(*discordgo.InteractionCreate); ok {
+			s.interactionHandler(dg, i, e.RawData)
+		}
+	})
+	s.dg.AddHandler(func(dg *discordgo.Session, r *discordgo.Ready) {
+		appID := r.User.ID
+		if r.Application != nil {
+			appID = r.Application.ID
+		}
+		err := s.syncAll(appID)
 		if err != nil {
 			Logger.Println("Error updating commands", err)
 		}
 		Logger.Println("Bot Ready")
 	})
-	if s.messageHandler != nil {
+	if s.messageHandler != nil || s.textCommands != nil {
 		s.dg.AddHandler(func(d *discordgo.Session, m *discordgo.MessageCreate) {
 			if m.Author.Bot {
 				return
@@ -121,8 +168,14 @@ func (s *Sevcord) Listen() {
 			ctx := &MessageCtx{
 				m: m.Message,
 				d: d,
+				s: s,
+			}
+			if s.textCommands != nil && m.GuildID != "" && s.runTextCommand(ctx) {
+				return
+			}
+			if s.messageHandler != nil {
+				s.messageHandler(ctx, m.Content)
 			}
-			s.messageHandler(ctx, m.Content)
 		})
 	}
 
@@ -130,7 +183,12 @@ func (s *Sevcord) Listen() {
 	if s.messageHandler != nil {
 		s.dg.Identify.Intents |= discordgo.IntentsGuildMessages
 	}
-	s.dg.Open()
+	if s.textCommands != nil {
+		s.dg.Identify.Intents |= discordgo.IntentsGuildMessages | discordgo.IntentMessageContent
+	}
+	if err := s.dg.Open(); err != nil {
+		return err
+	}
 
 	// Wait
 	stop := make(chan os.Signal, 1)
@@ -139,5 +197,13 @@ func (s *Sevcord) Listen() {
 	fmt.Println("Gracefully shutting down...")
 
 	// Close
-	s.dg.Close()
+	s.lock.RLock()
+	store := s.stateStore
+	s.lock.RUnlock()
+	if v, ok := store.(interface{ Flush() error }); ok {
+		if err := v.Flush(); err != nil {
+			Logger.Println("Error writing component state", err)
+		}
+	}
+	return s.dg.Close()
 }
@@ -0,0 +1,205 @@
+package sevcord
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultStateTTL is how long component state is kept by default
+const DefaultStateTTL = 24 * time.Hour
+
+// StateStore stores component params that are too long to fit into a custom ID. Implementations must be safe for concurrent use
+type StateStore interface {
+	Set(key, value string, expires time.Time) error
+	Get(key string) (value string, exists bool, err error)
+}
+
+type stateEntry struct {
+	Value   string    `json:"value"`
+	Expires time.Time `json:"expires"`
+}
+
+// memoryStateEvictInterval is how often MemoryStateStore removes all expired entries, expired entries that are looked up are removed immediately
+const memoryStateEvictInterval = 10 * time.Minute
+
+// MemoryStateStore keeps component state in memory, it is lost when the bot restarts
+type MemoryStateStore struct {
+	lock      *sync.Mutex
+	entries   map[string]stateEntry
+	lastEvict time.Time
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		lock:      &sync.Mutex{},
+		entries:   make(map[string]stateEntry),
+		lastEvict: time.Now(),
+	}
+}
+
+func (m *MemoryStateStore) Set(key, value string, expires time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if time.Since(m.lastEvict) > memoryStateEvictInterval {
+		evictExpired(m.entries)
+		m.lastEvict = time.Now()
+	}
+	m.entries[key] = stateEntry{Value: value, Expires: expires}
+	return nil
+}
+
+func (m *MemoryStateStore) Get(key string) (string, bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	v, exists := m.entries[key]
+	if !exists {
+		return "", false, nil
+	}
+	if time.Now().After(v.Expires) {
+		delete(m.entries, key)
+		return "", false, nil
+	}
+	return v.Value, true, nil
+}
+
+// FileStateFlushDelay is how long FileStateStore waits to write changes to its file, so that many components sent at once only cause one write
+var FileStateFlushDelay = time.Second
+
+// FileStateStore keeps component state in a JSON file so that components keep working after the bot restarts. Writes are batched, the whole file is rewritten at most once every FileStateFlushDelay. Call Flush before exiting so that recent state isn't lost, Listen does this when the bot shuts down
+type FileStateStore struct {
+	lock    *sync.Mutex
+	path    string
+	entries map[string]stateEntry
+	flush   *time.Timer // Set while a write is scheduled
+}
+
+// NewFileStateStore loads the state stored at path, creating it when the first entry is added if it doesn't exist
+func NewFileStateStore(path string) (*FileStateStore, error) {
+	f := &FileStateStore{
+		lock:    &sync.Mutex{},
+		path:    path,
+		entries: make(map[string]stateEntry),
+	}
+	dat, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(dat, &f.entries); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileStateStore) Set(key, value string, expires time.Time) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.entries[key] = stateEntry{Value: value, Expires: expires}
+	if f.flush == nil {
+		f.flush = time.AfterFunc(FileStateFlushDelay, func() {
+			if err := f.Flush(); err != nil {
+				Logger.Println("Error writing component state", err)
+			}
+		})
+	}
+	return nil
+}
+
+// Flush removes expired entries and writes the state to the file immediately
+func (f *FileStateStore) Flush() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.flush != nil {
+		f.flush.Stop()
+		f.flush = nil
+	}
+	evictExpired(f.entries)
+
+	// Write to a temporary file and rename so that a crash can't leave a half-written file
+	dat, err := json.Marshal(f.entries)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(dat)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *FileStateStore) Get(key string) (string, bool, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	v, exists := f.entries[key]
+	if !exists || time.Now().After(v.Expires) {
+		return "", false, nil
+	}
+	return v.Value, true, nil
+}
+
+func evictExpired(entries map[string]stateEntry) {
+	now := time.Now()
+	for k, v := range entries {
+		if now.After(v.Expires) {
+			delete(entries, k)
+		}
+	}
+}
+
+// SetStateStore changes where params too long for a custom ID are stored and how long they are kept. By default, they are kept in memory for DefaultStateTTL
+func (s *Sevcord) SetStateStore(store StateStore, ttl time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stateStore = store
+	s.stateTTL = ttl
+}
+
+func (s *Sevcord) storeStateKey(key, value string) error {
+	s.lock.RLock()
+	store, ttl := s.stateStore, s.stateTTL
+	s.lock.RUnlock()
+
+	return store.Set(key, value, time.Now().Add(ttl))
+}
+
+func (s *Sevcord) loadState(key string) (string, bool, error) {
+	s.lock.RLock()
+	store := s.stateStore
+	s.lock.RUnlock()
+
+	return store.Get(key)
+}
+
+// randomKey generates a short random key that is safe to put in a custom ID
+func randomKey() string {
+	b := make([]byte, 9)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,396 @@
+package sevcord
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// TextCommandOptions configures running slash commands from messages that start with a prefix, see Sevcord.EnableTextCommands
+type TextCommandOptions struct {
+	Prefix      string                    // Like "!", leave empty to only use GuildPrefix or Mention
+	GuildPrefix func(guild string) string // Optional, gets the prefix of a guild. Prefix is used if it returns an empty string
+	Mention     bool                      // Also allow mentioning the bot as the prefix
+}
+
+// EnableTextCommands lets members run slash commands by sending messages like `!config set "some key" 10`. Arguments are matched to options in order, quotes group words and the last string option gets the rest of the message. Attachment options are filled with the message's attachments. Only commands marked with SlashCommand.AllowText can be run this way. Middleware and command permissions are checked like for slash commands, using the permissions of the author's roles, which are cached for a few minutes unless the bot has the guilds intent. Messages that aren't commands are passed to the message handler. Note that this needs the privileged message content intent
+func (s *Sevcord) EnableTextCommands(opts TextCommandOptions) {
+	s.textCommands = &opts
+}
+
+// textCommandPrefix gets the content of a message after the prefix, returning false if it doesn't start with one
+func (s *Sevcord) textCommandPrefix(d *discordgo.Session, m *discordgo.Message) (prefix string, content string, ok bool) {
+	opts := s.textCommands
+	if opts.Mention && d.State != nil && d.State.User != nil {
+		for _, mention := range []string{"<@" + d.State.User.ID + ">", "<@!" + d.State.User.ID + ">"} {
+			if strings.HasPrefix(m.Content, mention) {
+				return mention + " ", strings.TrimPrefix(m.Content, mention), true
+			}
+		}
+	}
+	prefix = opts.Prefix
+	if opts.GuildPrefix != nil {
+		if v := opts.GuildPrefix(m.GuildID); v != "" {
+			prefix = v
+		}
+	}
+	if prefix == "" || !strings.HasPrefix(m.Content, prefix) {
+		return "", "", false
+	}
+	return prefix, strings.TrimPrefix(m.Content, prefix), true
+}
+
+// runTextCommand runs the command in a message, returning false if the message isn't a command
+func (s *Sevcord) runTextCommand(ctx *MessageCtx) bool {
+	prefix, content, ok := s.textCommandPrefix(ctx.d, ctx.m)
+	if !ok {
+		return false
+	}
+	tokens, err := tokenize(content)
+	if err != nil {
+		tokens = strings.Fields(content) // Still find the command so that the error can be shown
+	}
+	if len(tokens) == 0 {
+		return false
+	}
+
+	name := strings.ToLower(tokens[0])
+	v, exists := s.findCommand(ctx.m.GuildID, name)
+	if !exists {
+		return false
+	}
+	if err != nil {
+		s.textCommandError(ctx, "Invalid command: "+err.Error()+".")
+		return true
+	}
+	required := v.permissions()
+	tokens = tokens[1:]
+	path := name
+	for v.isGroup() {
+		group := v.(*SlashCommandGroup)
+		subcommands := make([]string, len(group.Children))
+		for i, child := range group.Children {
+			subcommands[i] = "`" + child.name() + "`"
+		}
+		if len(tokens) == 0 {
+			s.textCommandError(ctx, "Missing subcommand, use one of "+strings.Join(subcommands, ", ")+".")
+			return true
+		}
+		found := false
+		for _, child := range group.Children {
+			if child.name() == strings.ToLower(tokens[0]) {
+				v = child
+				found = true
+				break
+			}
+		}
+		if !found {
+			s.textCommandError(ctx, "Unknown subcommand `"+tokens[0]+"`, use one of "+strings.Join(subcommands, ", ")+".")
+			return true
+		}
+		path += " " + v.name()
+		tokens = tokens[1:]
+	}
+	cmd := v.(*SlashCommand)
+	if !cmd.Text {
+		s.textCommandError(ctx, "This command can only be used as a slash command, use `/"+path+"`.")
+		return true
+	}
+
+	// Discord checks permissions for slash commands, so they have to be checked here
+	if required != nil {
+		perms, _, err := ctxPermissions(ctx)
+		if err != nil {
+			Logger.Println("Error getting permissions", err)
+			s.textCommandError(ctx, "Couldn't check your permissions, please try again.")
+			return true
+		}
+		if !hasPermissions(perms, required) {
+			s.textCommandError(ctx, "You don't have permission to use this command.")
+			return true
+		}
+	}
+
+	args, err := textArgs(ctx, cmd, tokens)
+	if err != nil {
+		s.textCommandError(ctx, "Invalid command: "+err.Error()+".\nUsage: `"+prefix+strings.TrimPrefix(commandUsage(path, cmd), "/")+"`")
+		return true
+	}
+
+	s.lock.RLock()
+	module, inModule := s.commandModules[name]
+	s.lock.RUnlock()
+	if s.checkMiddleware(ctx, name) && (!inModule || module.checkMiddleware(ctx, name)) {
+		s.runTextHandler(ctx, path, cmd, args)
+	}
+	return true
+}
+
+// runTextHandler runs a command's handler, recovering if it panics so that a handler that doesn't support text commands can't crash the bot
+func (s *Sevcord) runTextHandler(ctx *MessageCtx, path string, cmd *SlashCommand, args []any) {
+	defer func() {
+		if r := recover(); r != nil {
+			Logger.Println("Panic in text command", path, r)
+			s.textCommandError(ctx, "Something went wrong, try using `/"+path+"` instead.")
+		}
+	}()
+	cmd.Handler(ctx, args)
+}
+
+func (s *Sevcord) textCommandError(ctx *MessageCtx, msg string) {
+	if err := ctx.Respond(NewMessage(msg)); err != nil {
+		Logger.Println("Error responding to text command", err)
+	}
+}
+
+// tokenize splits a message into words, words in single or double quotes are kept together and backslashes escape the next character
+func tokenize(content string) ([]string, error) {
+	out := make([]string, 0)
+	var current strings.Builder
+	inToken := false
+	var quote rune
+	escaped := false
+	for _, r := range content {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+
+		case r == '\\':
+			escaped = true
+			inToken = true
+
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+
+		case (r == '"' || r == '\'') && !inToken: // Only at the start of words so that apostrophes work
+			quote = r
+			inToken = true
+
+		case unicode.IsSpace(r):
+			if inToken {
+				out = append(out, current.String())
+				current.Reset()
+				inToken = false
+			}
+
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inToken {
+		out = append(out, current.String())
+	}
+	return out, nil
+}
+
+// textArgs converts the arguments of a text command to the values a slash command handler gets
+func textArgs(ctx *MessageCtx, cmd *SlashCommand, tokens []string) ([]any, error) {
+	lastText := -1 // Index of the last option that takes text, which gets the rest of the message if it is a string
+	for i, opt := range cmd.Options {
+		if opt.Kind != OptionKindAttachment {
+			lastText = i
+		}
+	}
+
+	out := make([]any, len(cmd.Options))
+	attachments := ctx.m.Attachments
+	for i, opt := range cmd.Options {
+		if opt.Kind == OptionKindAttachment {
+			if len(attachments) == 0 {
+				if opt.Required {
+					return nil, fmt.Errorf("missing attachment `%s`", opt.Name)
+				}
+				continue
+			}
+			out[i] = &SlashCommandAttachment{
+				Filename:    attachments[0].Filename,
+				URL:         attachments[0].URL,
+				ProxyURL:    attachments[0].ProxyURL,
+				ContentType: attachments[0].ContentType,
+			}
+			attachments = attachments[1:]
+			continue
+		}
+
+		if len(tokens) == 0 {
+			if opt.Required {
+				return nil, fmt.Errorf("missing argument `%s`", opt.Name)
+			}
+			continue
+		}
+		arg := tokens[0]
+		tokens = tokens[1:]
+		if i == lastText && opt.Kind == OptionKindString && len(tokens) > 0 {
+			arg = strings.Join(append([]string{arg}, tokens...), " ")
+			tokens = nil
+		}
+
+		v, err := textArg(ctx.d, opt, arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument `%s` %w", opt.Name, err)
+		}
+		out[i] = v
+	}
+	if len(tokens) > 0 {
+		return nil, errors.New("too many arguments")
+	}
+	return out, nil
+}
+
+// textArg converts an argument to the type that the option has in slash commands
+func textArg(d *discordgo.Session, opt Option, arg string) (any, error) {
+	switch opt.Kind {
+	case OptionKindString:
+		arg = textChoice(opt, arg)
+		if err := checkRange(opt, float64(utf8.RuneCountInString(arg)), " characters"); err != nil {
+			return nil, err
+		}
+		if err := checkChoice(opt, arg); err != nil {
+			return nil, err
+		}
+		return arg, nil
+
+	case OptionKindInt:
+		arg = textChoice(opt, arg)
+		v, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+		if err := checkRange(opt, float64(v), ""); err != nil {
+			return nil, err
+		}
+		return v, checkChoice(opt, arg)
+
+	case OptionKindFloat:
+		arg = textChoice(opt, arg)
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		if err := checkRange(opt, v, ""); err != nil {
+			return nil, err
+		}
+		return v, checkChoice(opt, arg)
+
+	case OptionKindBool:
+		switch strings.ToLower(arg) {
+		case "true", "yes", "y", "on", "1":
+			return true, nil
+
+		case "false", "no", "n", "off", "0":
+			return false, nil
+		}
+		return nil, errors.New("must be true or false")
+
+	case OptionKindUser:
+		id, ok := mentionID(arg, "<@!", "<@")
+		if !ok {
+			return nil, errors.New("must be a user mention or ID")
+		}
+		u, err := d.User(id)
+		if err != nil {
+			return nil, errors.New("must be an existing user")
+		}
+		return u, nil
+
+	case OptionKindChannel:
+		id, ok := mentionID(arg, "<#")
+		if !ok {
+			return nil, errors.New("must be a channel mention or ID")
+		}
+		if len(opt.ChannelTypes) > 0 {
+			ch, err := d.Channel(id)
+			if err != nil {
+				return nil, errors.New("must be an existing channel")
+			}
+			allowed := false
+			for _, kind := range opt.ChannelTypes {
+				allowed = allowed || ch.Type == kind
+			}
+			if !allowed {
+				return nil, errors.New("must be a channel of an allowed type")
+			}
+		}
+		return id, nil
+
+	case OptionKindRole:
+		id, ok := mentionID(arg, "<@&")
+		if !ok {
+			return nil, errors.New("must be a role mention or ID")
+		}
+		return id, nil
+	}
+	return nil, fmt.Errorf("can't be used in text commands, it is a %s option", opt.Kind)
+}
+
+// mentionID gets the ID from a mention with one of the prefixes or a raw ID
+func mentionID(arg string, prefixes ...string) (string, bool) {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(arg, prefix) && strings.HasSuffix(arg, ">") {
+			arg = arg[len(prefix) : len(arg)-1]
+			break
+		}
+	}
+	if arg == "" {
+		return "", false
+	}
+	for _, r := range arg {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return arg, true
+}
+
+// textChoice gets the value of the choice with the name, so that members can use the names they see in slash commands
+func textChoice(opt Option, arg string) string {
+	for _, choice := range opt.Choices {
+		if strings.EqualFold(choice.Name, arg) {
+			return choice.Value
+		}
+	}
+	return arg
+}
+
+func checkChoice(opt Option, arg string) error {
+	if len(opt.Choices) == 0 {
+		return nil
+	}
+	names := make([]string, len(opt.Choices))
+	for i, choice := range opt.Choices {
+		if choice.Value == arg {
+			return nil
+		}
+		names[i] = "`" + choice.Name + "`"
+	}
+	return errors.New("must be one of " + strings.Join(names, ", "))
+}
+
+// checkRange checks the min and max of an option, like discord does for slash commands. The unit is added after the limit in errors
+func checkRange(opt Option, v float64, unit string) error {
+	if opt.MinVal == nil {
+		return nil
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	if v < *opt.MinVal {
+		return fmt.Errorf("must be at least %s%s", format(*opt.MinVal), unit)
+	}
+	if opt.MaxVal != 0 && v > opt.MaxVal {
+		return fmt.Errorf("must be at most %s%s", format(opt.MaxVal), unit)
+	}
+	return nil
+}
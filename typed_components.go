@@ -0,0 +1,127 @@
+package sevcord
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+// MaxCustomIDLength is the longest custom ID discord accepts on a component
+const MaxCustomIDLength = 100
+
+// TypedButtonHandler is a ButtonHandler that receives params decoded into T
+type TypedButtonHandler[T any] func(ctx Ctx, params T)
+
+// TypedSelectHandler is a SelectHandler that receives params decoded into T
+type TypedSelectHandler[T any] func(ctx Ctx, params T, selected []string)
+
+// AddTypedButtonHandler adds a button handler whose params are decoded into T before the handler is called. Use NewTypedButton to create buttons for it
+func AddTypedButtonHandler[T any](s *Sevcord, id string, handler TypedButtonHandler[T]) {
+	s.AddButtonHandler(id, func(ctx Ctx, params string) {
+		v, err := decodeParams[T](params)
+		if err != nil {
+			Logger.Println("Error decoding params for button", id, err)
+			if ictx, ok := ctx.(*InteractionCtx); ok {
+				ictx.respondError("This component is outdated, try running the command again.")
+			}
+			return
+		}
+		handler(ctx, v)
+	})
+}
+
+// AddTypedSelectHandler adds a select handler whose params are decoded into T before the handler is called. Use NewTypedSelect to create select menus for it
+func AddTypedSelectHandler[T any](s *Sevcord, id string, handler TypedSelectHandler[T]) {
+	s.AddSelectHandler(id, func(ctx Ctx, params string, selected []string) {
+		v, err := decodeParams[T](params)
+		if err != nil {
+			Logger.Println("Error decoding params for select", id, err)
+			if ictx, ok := ctx.(*InteractionCtx); ok {
+				ictx.respondError("This component is outdated, try running the command again.")
+			}
+			return
+		}
+		handler(ctx, v, selected)
+	})
+}
+
+// NewTypedButton creates a button with params encoded from T, returning an error if the resulting custom ID is too long for discord
+func NewTypedButton[T any](label string, style ButtonStyle, handler string, params T) (*Button, error) {
+	p, err := encodeParams(handler, params)
+	if err != nil {
+		return nil, err
+	}
+	return NewButton(label, style, handler, p), nil
+}
+
+// NewTypedSelect creates a select menu with params encoded from T, returning an error if the resulting custom ID is too long for discord
+func NewTypedSelect[T any](placeholder string, handler string, params T) (*Select, error) {
+	p, err := encodeParams(handler, params)
+	if err != nil {
+		return nil, err
+	}
+	return NewSelect(placeholder, handler, p), nil
+}
+
+// encodeParams encodes structs as a JSON array of their exported fields in order (without the brackets), which is much shorter than an object. Other types are encoded as plain JSON
+func encodeParams[T any](handler string, params T) (string, error) {
+	var out string
+	val := reflect.ValueOf(params)
+	if val.Kind() == reflect.Struct {
+		fields := make([]any, 0, val.NumField())
+		for i := 0; i < val.NumField(); i++ {
+			if val.Type().Field(i).IsExported() {
+				fields = append(fields, val.Field(i).Interface())
+			}
+		}
+		v, err := json.Marshal(fields)
+		if err != nil {
+			return "", err
+		}
+		out = string(v[1 : len(v)-1])
+	} else {
+		v, err := json.Marshal(params)
+		if err != nil {
+			return "", err
+		}
+		out = string(v)
+	}
+
+	length := utf8.RuneCountInString(handler + componentSeperator + out)
+	if length > MaxCustomIDLength {
+		return "", fmt.Errorf("sevcord: custom id for handler %q is %d characters, max is %d", handler, length, MaxCustomIDLength)
+	}
+	return out, nil
+}
+
+func decodeParams[T any](params string) (T, error) {
+	var out T
+	val := reflect.ValueOf(&out).Elem()
+	if val.Kind() != reflect.Struct {
+		err := json.Unmarshal([]byte(params), &out)
+		return out, err
+	}
+
+	var fields []json.RawMessage
+	if err := json.Unmarshal([]byte("["+params+"]"), &fields); err != nil {
+		return out, err
+	}
+	ind := 0
+	for i := 0; i < val.NumField(); i++ {
+		if !val.Type().Field(i).IsExported() {
+			continue
+		}
+		if ind >= len(fields) {
+			return out, fmt.Errorf("sevcord: params %q are missing field %s", params, val.Type().Field(i).Name)
+		}
+		if err := json.Unmarshal(fields[ind], val.Field(i).Addr().Interface()); err != nil {
+			return out, fmt.Errorf("sevcord: field %s: %w", val.Type().Field(i).Name, err)
+		}
+		ind++
+	}
+	if ind != len(fields) {
+		return out, fmt.Errorf("sevcord: params %q have %d fields, expected %d", params, len(fields), ind)
+	}
+	return out, nil
+}
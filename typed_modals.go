@@ -0,0 +1,268 @@
+package sevcord
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TypedModalHandler handles a modal created with NewTypedModal. It is only called once every field is valid
+type TypedModalHandler[T any] func(ctx Ctx, values T)
+
+// maxModalInputs is the most inputs discord allows in a modal
+const maxModalInputs = 5
+
+// reopenModalHandler is the ID of the button handler that reopens typed modals with invalid values
+const reopenModalHandler = "sevcord.reopen"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+)
+
+type typedModalField struct {
+	index int // Index of struct field
+	input ModalInput
+}
+
+// NewTypedModal creates a modal with an input for every exported field of T, configured with the `modal` struct tag. For example:
+//
+//	Age int `modal:"label=Your age;placeholder=18;min=1;max=3;required"`
+//
+// Supported keys are id (defaults to the field name), label (defaults to the field name), placeholder, style (sentence or paragraph), min, max and required. Use `modal:"-"` to skip a field.
+// Fields can be strings, bools, ints, uints, floats, time.Duration or url.URL (and pointers to them). Non-zero fields of value are prefilled.
+// If any values are invalid, the user is shown what is wrong with a button to reopen the modal with their values prefilled
+func NewTypedModal[T any](title string, value T, handler TypedModalHandler[T]) (Modal, error) {
+	typ := reflect.TypeOf(value)
+	if typ.Kind() != reflect.Struct {
+		return Modal{}, fmt.Errorf("sevcord: typed modal must be a struct, got %s", typ)
+	}
+	fields, err := modalFields(typ)
+	if err != nil {
+		return Modal{}, err
+	}
+
+	val := reflect.ValueOf(value)
+	inputs := make([]ModalInput, len(fields))
+	for i, field := range fields {
+		inputs[i] = field.input
+		if !val.Field(field.index).IsZero() {
+			inputs[i].Value = formatModalValue(val.Field(field.index))
+		}
+	}
+
+	m := Modal{Title: title, Inputs: inputs}
+	m.Handler = func(ctx Ctx, values ModalValues) {
+		var out T
+		outV := reflect.ValueOf(&out).Elem()
+		problems := make([]string, 0)
+		for _, field := range fields {
+			raw := values[field.input.ID]
+			if err := parseModalValue(field.input, raw, outV.Field(field.index)); err != nil {
+				problems = append(problems, fmt.Sprintf("**%s**: %s", field.input.Label, err.Error()))
+			}
+		}
+		if len(problems) == 0 {
+			handler(ctx, out)
+			return
+		}
+
+		// Show problems with a button to fix them
+		ictx := ctx.(*InteractionCtx)
+		reopen := m
+		reopen.Inputs = make([]ModalInput, len(m.Inputs))
+		for i, inp := range m.Inputs {
+			reopen.Inputs[i] = inp.SetValue(values[inp.ID])
+		}
+		key := ictx.s.addReopenModal(reopen)
+		err := ictx.respondEphemeral(NewMessage("Please fix the following:\n" + strings.Join(problems, "\n")).
+			AddComponentRow(NewButton("Edit", ButtonStylePrimary, reopenModalHandler, key)))
+		if err != nil {
+			Logger.Println("Error responding to invalid modal", err)
+		}
+	}
+	return m, nil
+}
+
+func modalFields(typ reflect.Type) ([]typedModalField, error) {
+	fields := make([]typedModalField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag := f.Tag.Get("modal")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		if !modalTypeSupported(f.Type) {
+			return nil, fmt.Errorf("sevcord: modal field %s has unsupported type %s", f.Name, f.Type)
+		}
+
+		inp := ModalInput{
+			ID:        f.Name,
+			Label:     f.Name,
+			Style:     ModalInputStyleSentence,
+			MaxLength: 4000,
+		}
+		for _, opt := range strings.Split(tag, ";") {
+			if opt == "" {
+				continue
+			}
+			key, val, _ := strings.Cut(opt, "=")
+			var err error
+			switch key {
+			case "id":
+				inp.ID = val
+			case "label":
+				inp.Label = val
+			case "placeholder":
+				inp.Placeholder = val
+			case "style":
+				switch val {
+				case "sentence":
+					inp.Style = ModalInputStyleSentence
+				case "paragraph":
+					inp.Style = ModalInputStyleParagraph
+				default:
+					err = fmt.Errorf("unknown style %q", val)
+				}
+			case "min":
+				inp.MinLength, err = strconv.Atoi(val)
+			case "max":
+				inp.MaxLength, err = strconv.Atoi(val)
+			case "required":
+				inp.Required = true
+			default:
+				err = fmt.Errorf("unknown key %q", key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("sevcord: modal tag on field %s: %w", f.Name, err)
+			}
+		}
+		fields = append(fields, typedModalField{index: i, input: inp})
+	}
+	if len(fields) > maxModalInputs {
+		return nil, fmt.Errorf("sevcord: modal has %d fields, max is %d", len(fields), maxModalInputs)
+	}
+	return fields, nil
+}
+
+func modalTypeSupported(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == urlType || typ == durationType {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func formatModalValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+
+	case v.Type() == urlType:
+		u := v.Interface().(url.URL)
+		return u.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// parseModalValue validates raw and stores it in v
+func parseModalValue(inp ModalInput, raw string, v reflect.Value) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		if inp.Required {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	}
+	length := utf8.RuneCountInString(raw)
+	if inp.MinLength > 0 && length < inp.MinLength {
+		return fmt.Errorf("must be at least %d characters", inp.MinLength)
+	}
+	if inp.MaxLength > 0 && length > inp.MaxLength {
+		return fmt.Errorf("must be at most %d characters", inp.MaxLength)
+	}
+
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("must be a duration like `1h30m`")
+		}
+		v.SetInt(int64(d))
+		return nil
+
+	case v.Type() == urlType:
+		u, err := url.ParseRequestURI(raw)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("must be a link like `https://example.com`")
+		}
+		v.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a whole number")
+		}
+		v.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a positive whole number")
+		}
+		v.SetUint(i)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+func (s *Sevcord) reopenModal(ctx Ctx, params string) {
+	ictx := ctx.(*InteractionCtx)
+	m, exists := s.getReopenModal(params)
+	if !exists {
+		ictx.respondError("This modal has expired.")
+		return
+	}
+	if err := ictx.Modal(m); err != nil {
+		Logger.Println("Error reopening modal", err)
+	}
+}
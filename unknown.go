@@ -0,0 +1,39 @@
+package sevcord
+
+// UnknownInteractionKind is the kind of interaction that no handler was found for
+type UnknownInteractionKind int
+
+const (
+	UnknownInteractionCommand UnknownInteractionKind = iota
+	UnknownInteractionButton
+	UnknownInteractionSelect
+	UnknownInteractionModal
+)
+
+func (u UnknownInteractionKind) String() string {
+	return [...]string{"command", "button", "select menu", "modal"}[u]
+}
+
+// UnknownInteractionHandler is called when no handler is found for an interaction, for example a button left over from a removed feature. id is the command name or handler ID
+type UnknownInteractionHandler func(ctx Ctx, kind UnknownInteractionKind, id string)
+
+// SetUnknownInteractionHandler changes what happens when no handler is found for an interaction. By default, the ID is logged and the user is told that it is no longer available
+func (s *Sevcord) SetUnknownInteractionHandler(handler UnknownInteractionHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.unknownHandler = handler
+}
+
+func defaultUnknownInteractionHandler(ctx Ctx, kind UnknownInteractionKind, id string) {
+	Logger.Println("No handler for", kind, id)
+	ctx.(*InteractionCtx).respondError("This " + kind.String() + " is no longer available.")
+}
+
+func (s *Sevcord) unknownInteraction(ctx *InteractionCtx, kind UnknownInteractionKind, id string) {
+	s.lock.RLock()
+	handler := s.unknownHandler
+	s.lock.RUnlock()
+
+	handler(ctx, kind, id)
+}
@@ -0,0 +1,227 @@
+package sevcord
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationProblem is a single problem found during validation, with the path to what is invalid
+type ValidationProblem struct {
+	Path    string
+	Message string
+}
+
+// ValidationError lists every problem found while validating something before it is sent to discord
+type ValidationError struct {
+	Problems []ValidationProblem
+}
+
+func (v *ValidationError) Error() string {
+	problems := make([]string, len(v.Problems))
+	for i, p := range v.Problems {
+		problems[i] = p.Path + ": " + p.Message
+	}
+	return "sevcord: validation failed: " + strings.Join(problems, "; ")
+}
+
+func (v *ValidationError) add(path string, format string, args ...any) {
+	v.Problems = append(v.Problems, ValidationProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// maxLength adds a problem if val is longer than max characters
+func (v *ValidationError) maxLength(path string, val string, max int) {
+	if l := utf8.RuneCountInString(val); l > max {
+		v.add(path, "is %d characters, max is %d", l, max)
+	}
+}
+
+// err returns nil if there are no problems, so that a nil *ValidationError isn't returned as a non-nil error
+func (v *ValidationError) err() error {
+	if len(v.Problems) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Discord limits for messages
+const (
+	maxContentLength     = 2000
+	maxEmbeds            = 10
+	maxEmbedTotal        = 6000
+	maxEmbedTitle        = 256
+	maxEmbedDescription  = 4096
+	maxEmbedFields       = 25
+	maxEmbedFieldName    = 256
+	maxEmbedFieldValue   = 1024
+	maxEmbedFooter       = 2048
+	maxEmbedAuthor       = 256
+	maxFiles             = 10
+	maxComponentRows     = 5
+	maxButtonsPerRow     = 5
+	maxButtonLabel       = 80
+	maxSelectPlaceholder = 150
+	maxSelectOptionText  = 100
+)
+
+// Validate checks that discord will accept the message, returning a *ValidationError listing every problem if not
+func (m MessageSend) Validate() error {
+	v := &ValidationError{}
+	v.maxLength("content", m.content, maxContentLength)
+
+	// Embeds
+	if len(m.embeds) > maxEmbeds {
+		v.add("embeds", "has %d embeds, max is %d", len(m.embeds), maxEmbeds)
+	}
+	total := 0
+	for i, e := range m.embeds {
+		path := fmt.Sprintf("embeds[%d]", i)
+		v.maxLength(path+".title", e.title, maxEmbedTitle)
+		v.maxLength(path+".description", e.description, maxEmbedDescription)
+		v.maxLength(path+".footer", e.footerText, maxEmbedFooter)
+		v.maxLength(path+".author", e.authorName, maxEmbedAuthor)
+		if len(e.fields) > maxEmbedFields {
+			v.add(path+".fields", "has %d fields, max is %d", len(e.fields), maxEmbedFields)
+		}
+		total += utf8.RuneCountInString(e.title) + utf8.RuneCountInString(e.description) + utf8.RuneCountInString(e.footerText) + utf8.RuneCountInString(e.authorName)
+		for j, f := range e.fields {
+			fpath := fmt.Sprintf("%s.fields[%d]", path, j)
+			if f.name == "" {
+				v.add(fpath+".name", "is empty")
+			}
+			if f.value == "" {
+				v.add(fpath+".value", "is empty")
+			}
+			v.maxLength(fpath+".name", f.name, maxEmbedFieldName)
+			v.maxLength(fpath+".value", f.value, maxEmbedFieldValue)
+			total += utf8.RuneCountInString(f.name) + utf8.RuneCountInString(f.value)
+		}
+	}
+	if total > maxEmbedTotal {
+		v.add("embeds", "have %d characters in total, max is %d", total, maxEmbedTotal)
+	}
+
+	if len(m.files) > maxFiles {
+		v.add("files", "has %d files, max is %d", len(m.files), maxFiles)
+	}
+
+	// Components
+	if len(m.components) > maxComponentRows {
+		v.add("components", "has %d rows, max is %d", len(m.components), maxComponentRows)
+	}
+	ids := make(map[string]string) // custom ID -> path
+	checkID := func(path, handler, params string) {
+		id := handler + componentSeperator + params
+		if other, exists := ids[id]; exists {
+			v.add(path, "has the same handler and params as %s", other)
+		}
+		ids[id] = path
+		if strings.Contains(handler, componentSeperator) || strings.Contains(handler, metaSeperator) {
+			v.add(path+".handler", "can't contain %q or %q", componentSeperator, metaSeperator)
+		}
+	}
+	for i, row := range m.components {
+		rpath := fmt.Sprintf("components[%d]", i)
+		if len(row) == 0 {
+			v.add(rpath, "is empty")
+		}
+		if len(row) > maxButtonsPerRow {
+			v.add(rpath, "has %d components, max is %d", len(row), maxButtonsPerRow)
+		}
+		for j, comp := range row {
+			path := fmt.Sprintf("%s[%d]", rpath, j)
+			switch c := comp.(type) {
+			case *Button:
+				v.maxLength(path+".label", c.Label, maxButtonLabel)
+				if c.Label == "" && c.Emoji == nil {
+					v.add(path, "must have a label or emoji")
+				}
+				if c.Style == ButtonStyleLink {
+					if c.URL == "" {
+						v.add(path+".url", "is required for link buttons")
+					}
+				} else {
+					if c.URL != "" {
+						v.add(path+".url", "can only be set on link buttons")
+					}
+					checkID(path, c.Handler, c.Params)
+				}
+
+			case *Select:
+				if len(row) > 1 {
+					v.add(path, "must be the only component in its row")
+				}
+				v.maxLength(path+".placeholder", c.Placeholder, maxSelectPlaceholder)
+				checkID(path, c.Handler, c.Params)
+				maxValues := c.MaxValues
+				if maxValues == 0 { // Discord defaults to 1
+					maxValues = 1
+				}
+				if c.MinValues < 0 || c.MinValues > maxSelectOptions {
+					v.add(path+".min_values", "must be between 0 and %d", maxSelectOptions)
+				}
+				if maxValues < 0 || maxValues > maxSelectOptions {
+					v.add(path+".max_values", "must be between 1 and %d", maxSelectOptions)
+				}
+				if c.MinValues > maxValues {
+					v.add(path+".min_values", "is greater than max_values")
+				}
+				if c.Kind != SelectKindString {
+					if len(c.Options) > 0 {
+						v.add(path+".options", "can only be set on string select menus")
+					}
+					if len(c.Defaults) > maxValues {
+						v.add(path+".default_values", "has %d values, max_values is %d", len(c.Defaults), maxValues)
+					}
+					for k, def := range c.Defaults {
+						if !selectDefaultAllowed(c.Kind, def.Kind) {
+							v.add(fmt.Sprintf("%s.default_values[%d]", path, k), "%s can't be a default value of this select menu", def.Kind)
+						}
+					}
+					continue
+				}
+				if len(c.Defaults) > 0 {
+					v.add(path+".default_values", "can't be set on string select menus, use SelectOption.Default")
+				}
+				if len(c.Options) == 0 {
+					v.add(path+".options", "is empty")
+				}
+				if len(c.Options) > maxSelectOptions {
+					v.add(path+".options", "has %d options, max is %d", len(c.Options), maxSelectOptions)
+				}
+				if maxValues > len(c.Options) {
+					v.add(path+".max_values", "is greater than the number of options")
+				}
+				values := make(map[string]struct{}, len(c.Options))
+				for k, opt := range c.Options {
+					opath := fmt.Sprintf("%s.options[%d]", path, k)
+					if _, exists := values[opt.ID]; exists {
+						v.add(opath+".id", "%q is used by another option", opt.ID)
+					}
+					values[opt.ID] = struct{}{}
+					v.maxLength(opath+".label", opt.Label, maxSelectOptionText)
+					v.maxLength(opath+".id", opt.ID, maxSelectOptionText)
+					v.maxLength(opath+".description", opt.Description, maxSelectOptionText)
+				}
+			}
+		}
+	}
+	return v.err()
+}
+
+func selectDefaultAllowed(kind SelectKind, def SelectDefaultValueKind) bool {
+	switch kind {
+	case SelectKindUser:
+		return def == SelectDefaultValueUser
+
+	case SelectKindRole:
+		return def == SelectDefaultValueRole
+
+	case SelectKindMentionable:
+		return def == SelectDefaultValueUser || def == SelectDefaultValueRole
+
+	case SelectKindChannel:
+		return def == SelectDefaultValueChannel
+	}
+	return false
+}
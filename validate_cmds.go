@@ -0,0 +1,149 @@
+package sevcord
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Discord limits for commands
+const (
+	maxCommandDescription = 100
+	maxCommandOptions     = 25
+	maxChoices            = 25
+	maxChoiceLength       = 100
+	maxSubcommandDepth    = 2 // Group > group > command
+)
+
+var commandNameRegex = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// Validate checks that discord will accept the command group and everything in it, returning a *ValidationError listing every problem with its path if not
+func (s *SlashCommandGroup) Validate() error {
+	v := &ValidationError{}
+	s.validate(v, "/"+s.Name, 0)
+	return v.err()
+}
+
+// Validate checks that discord will accept the command, returning a *ValidationError listing every problem with its path if not
+func (s *SlashCommand) Validate() error {
+	v := &ValidationError{}
+	s.validate(v, "/"+s.Name, 0)
+	return v.err()
+}
+
+// Validate checks that discord will accept the context menu, returning a *ValidationError if not
+func (c *ContextMenuCommand) Validate() error {
+	v := &ValidationError{}
+	path := c.Name
+	if l := utf8.RuneCountInString(c.Name); l < 1 || l > 32 {
+		v.add(path, "name must be 1-32 characters, is %d", l)
+	}
+	if c.Kind != ContextMenuKindMessage && c.Kind != ContextMenuKindUser {
+		v.add(path, "unknown context menu kind %d", c.Kind)
+	}
+	if c.Handler == nil {
+		v.add(path, "has no handler")
+	}
+	return v.err()
+}
+
+func validateNameDescription(v *ValidationError, path, name, description string) {
+	if !commandNameRegex.MatchString(name) {
+		v.add(path, "name %q must be 1-32 letters, numbers, dashes or underscores", name)
+	} else if strings.ToLower(name) != name {
+		v.add(path, "name %q must be lowercase", name)
+	}
+	if l := utf8.RuneCountInString(description); l < 1 || l > maxCommandDescription {
+		v.add(path, "description must be 1-%d characters, is %d", maxCommandDescription, l)
+	}
+}
+
+func (s *SlashCommandGroup) validate(v *ValidationError, path string, depth int) {
+	validateNameDescription(v, path, s.Name, s.Description)
+	if depth >= maxSubcommandDepth {
+		v.add(path, "subcommand groups can only be nested %d levels deep", maxSubcommandDepth)
+	}
+	if len(s.Children) == 0 {
+		v.add(path, "has no subcommands")
+	}
+	if len(s.Children) > maxCommandOptions {
+		v.add(path, "has %d subcommands, max is %d", len(s.Children), maxCommandOptions)
+	}
+	if depth > 0 && s.Permissions != nil {
+		v.add(path, "permissions can only be set on top-level commands")
+	}
+	names := make(map[string]struct{}, len(s.Children))
+	for _, child := range s.Children {
+		if _, exists := names[child.name()]; exists {
+			v.add(path, "has more than one subcommand named %q", child.name())
+		}
+		names[child.name()] = struct{}{}
+		child.validate(v, path+" "+child.name(), depth+1)
+	}
+}
+
+func (s *SlashCommand) validate(v *ValidationError, path string, depth int) {
+	validateNameDescription(v, path, s.Name, s.Description)
+	if s.Handler == nil {
+		v.add(path, "has no handler")
+	}
+	if depth > 0 && s.Permissions != nil {
+		v.add(path, "permissions can only be set on top-level commands")
+	}
+	if len(s.Options) > maxCommandOptions {
+		v.add(path, "has %d options, max is %d", len(s.Options), maxCommandOptions)
+	}
+
+	names := make(map[string]struct{}, len(s.Options))
+	optional := false
+	for _, opt := range s.Options {
+		opath := path + " > " + opt.Name
+		validateNameDescription(v, opath, opt.Name, opt.Description)
+		if _, exists := names[opt.Name]; exists {
+			v.add(path, "has more than one option named %q", opt.Name)
+		}
+		names[opt.Name] = struct{}{}
+
+		if opt.Required && optional {
+			v.add(opath, "required options must come before optional options")
+		}
+		optional = optional || !opt.Required
+
+		if opt.Kind < OptionKindString || opt.Kind > OptionKindAttachment {
+			v.add(opath, "unknown option kind %d", opt.Kind)
+			continue
+		}
+		numeric := opt.Kind == OptionKindInt || opt.Kind == OptionKindFloat
+		if opt.Autocomplete != nil && opt.Kind != OptionKindString && !numeric {
+			v.add(opath, "autocomplete is only allowed on string, int and float options")
+		}
+		if len(opt.Choices) > 0 {
+			if opt.Autocomplete != nil {
+				v.add(opath, "can't have both choices and autocomplete")
+			}
+			if opt.Kind != OptionKindString && !numeric {
+				v.add(opath, "choices are only allowed on string, int and float options")
+			}
+		}
+		if len(opt.Choices) > maxChoices {
+			v.add(opath, "has %d choices, max is %d", len(opt.Choices), maxChoices)
+		}
+		for i, choice := range opt.Choices {
+			cpath := fmt.Sprintf("%s > choices[%d]", opath, i)
+			if l := utf8.RuneCountInString(choice.Name); l < 1 || l > maxChoiceLength {
+				v.add(cpath, "name must be 1-%d characters, is %d", maxChoiceLength, l)
+			}
+			v.maxLength(cpath, choice.Value, maxChoiceLength)
+			if numeric {
+				if _, err := strconv.ParseFloat(choice.Value, 64); err != nil {
+					v.add(cpath, "value %q must be a number", choice.Value)
+				}
+			}
+		}
+		if opt.MinVal != nil && opt.MaxVal != 0 && *opt.MinVal > opt.MaxVal {
+			v.add(opath, "min is greater than max")
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package sevcord
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// wizardHandler is the ID of the button and select handler that drives wizards
+const wizardHandler = "sevcord.wizard"
+
+// DefaultWizardTimeout is how long a wizard session lasts by default
+const DefaultWizardTimeout = 10 * time.Minute
+
+// Wizard actions, stored in the params of its components after the index of the step they belong to
+const (
+	wizardActionChoose = "c" // Followed by the index of the button chosen
+	wizardActionSelect = "s"
+	wizardActionModal  = "m"
+	wizardActionBack   = "b"
+	wizardActionCancel = "x"
+)
+
+// WizardPrompt creates the message shown for a step, the wizard adds its components to it
+type WizardPrompt func(sess *WizardSession) MessageSend
+
+// WizardStep is a step of a Wizard, where the user presses a button, uses a select menu or fills out a modal
+type WizardStep struct {
+	Name   string
+	Prompt WizardPrompt
+
+	// Only one of these is set
+	buttons    []*Button
+	sel        *Select
+	modal      *Modal
+	modalLabel string
+
+	next func(sess *WizardSession) string
+}
+
+// NewButtonStep creates a step where the user presses one of the buttons. The Params of the button pressed is stored as the step's value, the Handler of the buttons is ignored
+func NewButtonStep(name string, prompt WizardPrompt, buttons ...*Button) *WizardStep {
+	return &WizardStep{Name: name, Prompt: prompt, buttons: buttons}
+}
+
+// NewSelectStep creates a step where the user uses a select menu. The selected values are stored as the step's value, the Handler and Params of the select menu are ignored
+func NewSelectStep(name string, prompt WizardPrompt, sel *Select) *WizardStep {
+	return &WizardStep{Name: name, Prompt: prompt, sel: sel}
+}
+
+// NewModalStep creates a step where the user presses a button with the label provided to fill out a modal. The ModalValues are stored as the step's value, the handler of the modal is ignored
+func NewModalStep(name string, prompt WizardPrompt, label string, modal Modal) *WizardStep {
+	return &WizardStep{Name: name, Prompt: prompt, modal: &modal, modalLabel: label}
+}
+
+// Then sets a function that picks the name of the step to go to after this one. If it returns an empty string, the next step in order is used. Return WizardFinish to finish the wizard
+func (w *WizardStep) Then(next func(sess *WizardSession) string) *WizardStep {
+	w.next = next
+	return w
+}
+
+// WizardFinish can be returned from the function passed to WizardStep.Then to finish the wizard
+const WizardFinish = "sevcord.finish"
+
+// WizardHandler is called when a wizard is finished or cancelled. It should respond to ctx, which updates the wizard's message
+type WizardHandler func(ctx Ctx, sess *WizardSession)
+
+// Wizard guides a user through a sequence of steps, with buttons to go back or cancel
+type Wizard struct {
+	steps      []*WizardStep
+	onComplete WizardHandler
+	onCancel   WizardHandler
+	timeout    time.Duration
+}
+
+func NewWizard(onComplete WizardHandler, steps ...*WizardStep) *Wizard {
+	return &Wizard{
+		steps:      steps,
+		onComplete: onComplete,
+		timeout:    DefaultWizardTimeout,
+	}
+}
+
+// OnCancel sets the handler called when the user cancels the wizard. By default, the message says that the wizard was cancelled
+func (w *Wizard) OnCancel(handler WizardHandler) *Wizard {
+	w.onCancel = handler
+	return w
+}
+
+// Timeout changes how long the user has to complete the wizard. Note that interactions can only be edited for 15 minutes
+func (w *Wizard) Timeout(timeout time.Duration) *Wizard {
+	w.timeout = timeout
+	return w
+}
+
+// WizardSession stores the progress of a user through a wizard
+type WizardSession struct {
+	User   string         // ID of the user who started the wizard
+	Values map[string]any // Values of each step that has been completed, by step name
+
+	lock    *sync.Mutex
+	key     string
+	w       *Wizard
+	step    int
+	history []int
+	expires time.Time
+	done    bool        // Completed, cancelled or expired
+	timer   *time.Timer // Disables the wizard's components when it expires
+}
+
+// String gets the value of a step created with NewButtonStep
+func (w *WizardSession) String(step string) string {
+	v, _ := w.Values[step].(string)
+	return v
+}
+
+// Strings gets the value of a step created with NewSelectStep
+func (w *WizardSession) Strings(step string) []string {
+	v, _ := w.Values[step].([]string)
+	return v
+}
+
+// ModalValues gets the value of a step created with NewModalStep
+func (w *WizardSession) ModalValues(step string) ModalValues {
+	v, _ := w.Values[step].(ModalValues)
+	return v
+}
+
+// Start responds with the first step of the wizard. Only the author of ctx can use it
+func (w *Wizard) Start(ctx Ctx) error {
+	if len(w.steps) == 0 {
+		return errors.New("sevcord: wizard has no steps")
+	}
+	s, editable, err := ctxEditable(ctx)
+	if err != nil {
+		return err
+	}
+	sess := &WizardSession{
+		User:    ctxUser(ctx).ID,
+		Values:  make(map[string]any),
+		lock:    &sync.Mutex{},
+		key:     randomKey(),
+		w:       w,
+		expires: time.Now().Add(w.timeout),
+	}
+
+	s.lock.Lock()
+	now := time.Now()
+	for k, v := range s.wizards {
+		if now.After(v.expires) {
+			delete(s.wizards, k)
+		}
+	}
+	s.wizards[sess.key] = sess
+	s.lock.Unlock()
+
+	sess.lock.Lock()
+	defer sess.lock.Unlock()
+	edit, err := editable.respondEditable(sess.render())
+	if err != nil {
+		s.lock.Lock()
+		delete(s.wizards, sess.key)
+		s.lock.Unlock()
+		return err
+	}
+	sess.timer = time.AfterFunc(w.timeout, func() { sess.expire(s, edit) })
+	return nil
+}
+
+// expire disables the components of the current step, unless the wizard has already finished
+func (w *WizardSession) expire(s *Sevcord, edit componentEditor) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.done {
+		return
+	}
+	w.finish(s)
+
+	msg := w.render()
+	msg.components = msg.components.disabled()
+	v, err := s.messageDg(msg)
+	if err == nil {
+		err = edit(v.Components)
+	}
+	if err != nil {
+		Logger.Println("Error disabling expired wizard", err)
+	}
+}
+
+// finish removes the session and stops its timer. The session's lock must be held
+func (w *WizardSession) finish(s *Sevcord) {
+	w.done = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	s.lock.Lock()
+	delete(s.wizards, w.key)
+	s.lock.Unlock()
+}
+
+// render creates the message for the current step
+func (w *WizardSession) render() MessageSend {
+	step := w.w.steps[w.step]
+	msg := step.Prompt(w)
+	params := func(action string) string {
+		return strings.Join([]string{w.key, strconv.Itoa(w.step), action}, componentSeperator)
+	}
+
+	switch {
+	case step.sel != nil:
+		sel := *step.sel
+		sel.Handler = wizardHandler
+		sel.Params = params(wizardActionSelect)
+		msg = msg.AddComponentRow(&sel)
+
+	case step.modal != nil:
+		msg = msg.AddComponentRow(NewButton(step.modalLabel, ButtonStylePrimary, wizardHandler, params(wizardActionModal)))
+
+	default:
+		row := make([]Component, 0, len(step.buttons))
+		for i, btn := range step.buttons {
+			b := *btn
+			b.Handler = wizardHandler
+			b.Params = params(wizardActionChoose + strconv.Itoa(i))
+			row = append(row, &b)
+			if len(row) == 5 || i == len(step.buttons)-1 { // Discord allows 5 buttons per row
+				msg = msg.AddComponentRow(row...)
+				row = make([]Component, 0, len(step.buttons))
+			}
+		}
+	}
+
+	msg = msg.AddComponentRow(
+		NewButton("Back", ButtonStyleSecondary, wizardHandler, params(wizardActionBack)).SetDisabled(len(w.history) == 0),
+		NewButton("Cancel", ButtonStyleDanger, wizardHandler, params(wizardActionCancel)),
+	)
+	return msg
+}
+
+// advance records the value of the current step and responds with the next one, or finishes the wizard
+func (w *WizardSession) advance(s *Sevcord, ctx *InteractionCtx, value any) {
+	step := w.w.steps[w.step]
+	w.Values[step.Name] = value
+
+	next := w.step + 1
+	if step.next != nil {
+		name := step.next(w)
+		if name == WizardFinish {
+			next = len(w.w.steps)
+		} else if name != "" {
+			next = -1
+			for i, v := range w.w.steps {
+				if v.Name == name {
+					next = i
+					break
+				}
+			}
+			if next == -1 {
+				Logger.Println("Unknown wizard step", name)
+				ctx.respondError("Something went wrong, please try again.")
+				return
+			}
+		}
+	}
+
+	if next >= len(w.w.steps) {
+		w.finish(s)
+		w.w.onComplete(ctx, w)
+		return
+	}
+	w.history = append(w.history, w.step)
+	w.step = next
+	if err := ctx.Respond(w.render()); err != nil {
+		Logger.Println("Error updating wizard", err)
+	}
+}
+
+func (s *Sevcord) wizardInteraction(ctx Ctx, params string, selected []string) {
+	ictx := ctx.(*InteractionCtx)
+	parts := strings.SplitN(params, componentSeperator, 3)
+	if len(parts) != 3 || parts[2] == "" {
+		ictx.respondError("This component is invalid.")
+		return
+	}
+	stepInd, err := strconv.Atoi(parts[1])
+	if err != nil {
+		ictx.respondError("This component is invalid.")
+		return
+	}
+
+	s.lock.RLock()
+	sess, exists := s.wizards[parts[0]]
+	s.lock.RUnlock()
+	if !exists || time.Now().After(sess.expires) {
+		ictx.respondError("This wizard has expired.")
+		return
+	}
+	if sess.User != interactionUser(ictx.i).ID {
+		ictx.respondError("You can't use this wizard.")
+		return
+	}
+
+	sess.lock.Lock()
+	defer sess.lock.Unlock()
+	if sess.done {
+		ictx.respondError("This wizard has expired.")
+		return
+	}
+	if stepInd != sess.step { // For example, a double click after the first click moved to the next step
+		ictx.respondError("This step has already been completed.")
+		return
+	}
+	step := sess.w.steps[sess.step]
+	switch action := parts[2]; action[:1] {
+	case wizardActionChoose:
+		ind, err := strconv.Atoi(action[1:])
+		if err != nil || ind < 0 || ind >= len(step.buttons) {
+			ictx.respondError("This component is invalid.")
+			return
+		}
+		sess.advance(s, ictx, step.buttons[ind].Params)
+
+	case wizardActionSelect:
+		sess.advance(s, ictx, selected)
+
+	case wizardActionModal:
+		if step.modal == nil {
+			ictx.respondError("This component is invalid.")
+			return
+		}
+		m := *step.modal
+		m.HandlerID = ""
+		m.Handler = func(ctx Ctx, values ModalValues) {
+			mctx := ctx.(*InteractionCtx)
+			mctx.modal = false // Modals opened from a component can update the component's message
+			sess.lock.Lock()
+			defer sess.lock.Unlock()
+			if sess.done || sess.step != stepInd {
+				mctx.respondError("This step has already been completed.")
+				return
+			}
+			sess.advance(s, mctx, values)
+		}
+		if err := ictx.Modal(m); err != nil {
+			Logger.Println("Error opening wizard modal", err)
+		}
+
+	case wizardActionBack:
+		if len(sess.history) == 0 {
+			ictx.respondError("There is no previous step.")
+			return
+		}
+		sess.step = sess.history[len(sess.history)-1]
+		sess.history = sess.history[:len(sess.history)-1]
+		delete(sess.Values, sess.w.steps[sess.step].Name)
+		if err := ictx.Respond(sess.render()); err != nil {
+			Logger.Println("Error updating wizard", err)
+		}
+
+	case wizardActionCancel:
+		sess.finish(s)
+		if sess.w.onCancel != nil {
+			sess.w.onCancel(ictx, sess)
+			return
+		}
+		if err := ictx.Respond(NewMessage("Cancelled.")); err != nil {
+			Logger.Println("Error updating wizard", err)
+		}
+
+	default:
+		ictx.respondError("This component is invalid.")
+	}
+}